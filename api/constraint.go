@@ -4,16 +4,17 @@
 package api
 
 const (
-	ConstraintDistinctProperty  = "distinct_property"
-	ConstraintDistinctHosts     = "distinct_hosts"
-	ConstraintRegex             = "regexp"
-	ConstraintVersion           = "version"
-	ConstraintSemver            = "semver"
-	ConstraintSetContains       = "set_contains"
-	ConstraintSetContainsAll    = "set_contains_all"
-	ConstraintSetContainsAny    = "set_contains_any"
-	ConstraintAttributeIsSet    = "is_set"
-	ConstraintAttributeIsNotSet = "is_not_set"
+	ConstraintDistinctProperty   = "distinct_property"
+	ConstraintDistinctHosts      = "distinct_hosts"
+	ConstraintRegex              = "regexp"
+	ConstraintVersion            = "version"
+	ConstraintSemver             = "semver"
+	ConstraintSetContains        = "set_contains"
+	ConstraintSetContainsAll     = "set_contains_all"
+	ConstraintSetContainsAny     = "set_contains_any"
+	ConstraintSetContainsVersion = "set_contains_version"
+	ConstraintAttributeIsSet     = "is_set"
+	ConstraintAttributeIsNotSet  = "is_not_set"
 )
 
 // Constraint is used to serialize a job placement constraint.
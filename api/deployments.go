@@ -207,6 +207,9 @@ type DeploymentState struct {
 	PlacedAllocs      int
 	HealthyAllocs     int
 	UnhealthyAllocs   int
+	Prefetch          bool
+	PrefetchNodes     int
+	PrefetchedNodes   int
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"net/url"
+)
+
+const (
+	// HostVolumeStatePending is the state of a dynamic host volume that has
+	// not yet been fingerprinted by its node.
+	HostVolumeStatePending = "pending"
+
+	// HostVolumeStateReady is the state of a dynamic host volume that can be
+	// scheduled.
+	HostVolumeStateReady = "ready"
+)
+
+// HostVolume is a host volume created through the API rather than declared in
+// the client configuration.
+type HostVolume struct {
+	// ID is generated by the server on create.
+	ID string `hcl:"id"`
+
+	// Name is the name the volume is requested by in a job's volume block.
+	Name string `hcl:"name"`
+
+	Namespace string `hcl:"namespace"`
+
+	// PluginID is the host volume plugin used to provision the volume. It
+	// defaults to the built-in "mkdir" plugin.
+	PluginID string `mapstructure:"plugin_id" hcl:"plugin_id"`
+
+	// NodePool and Constraints are used to select a node when NodeID isn't
+	// set.
+	NodePool    string        `mapstructure:"node_pool" hcl:"node_pool"`
+	Constraints []*Constraint `hcl:"constraint,block"`
+
+	// NodeID is the node the volume is provisioned on.
+	NodeID string `mapstructure:"node_id" hcl:"node_id"`
+
+	RequestedCapacityMinBytes int64 `mapstructure:"capacity_min" hcl:"capacity_min"`
+	RequestedCapacityMaxBytes int64 `mapstructure:"capacity_max" hcl:"capacity_max"`
+	CapacityBytes             int64
+
+	// Parameters are opaque key/values passed to the plugin.
+	Parameters map[string]string `hcl:"parameters"`
+
+	// HostPath is the path of the volume on the node.
+	HostPath string
+
+	// State is one of the HostVolumeState* constants.
+	State string
+
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumeStub is used for listing host volumes.
+type HostVolumeStub struct {
+	ID            string
+	Name          string
+	Namespace     string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         string
+
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumes is used to access the dynamic host volume endpoints.
+type HostVolumes struct {
+	client *Client
+}
+
+// HostVolumes returns a handle on the HostVolumes endpoint.
+func (c *Client) HostVolumes() *HostVolumes {
+	return &HostVolumes{client: c}
+}
+
+// HostVolumeCreateRequest is used to create a host volume.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+}
+
+// HostVolumeCreateResponse is the response to a host volume create request.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+}
+
+// HostVolumeListRequest filters the list of host volumes.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+}
+
+// Create provisions a host volume on a node and registers it with Nomad.
+func (hv *HostVolumes) Create(vol *HostVolume, w *WriteOptions) (*HostVolume, *WriteMeta, error) {
+	req := &HostVolumeCreateRequest{Volume: vol}
+	var out HostVolumeCreateResponse
+	wm, err := hv.client.put("/v1/volume/host/create", req, &out, w)
+	if err != nil {
+		return nil, wm, err
+	}
+	return out.Volume, wm, nil
+}
+
+// Get returns a single host volume.
+func (hv *HostVolumes) Get(id string, q *QueryOptions) (*HostVolume, *QueryMeta, error) {
+	var out HostVolume
+	qm, err := hv.client.query("/v1/volume/host/"+url.PathEscape(id), &out, q)
+	if err != nil {
+		return nil, qm, err
+	}
+	return &out, qm, nil
+}
+
+// List returns the host volumes, optionally filtered by node or node pool.
+func (hv *HostVolumes) List(req *HostVolumeListRequest, q *QueryOptions) ([]*HostVolumeStub, *QueryMeta, error) {
+	qp := url.Values{}
+	qp.Set("type", "host")
+	if req != nil {
+		if req.NodeID != "" {
+			qp.Set("node_id", req.NodeID)
+		}
+		if req.NodePool != "" {
+			qp.Set("node_pool", req.NodePool)
+		}
+	}
+
+	var out []*HostVolumeStub
+	qm, err := hv.client.query("/v1/volumes?"+qp.Encode(), &out, q)
+	if err != nil {
+		return nil, qm, err
+	}
+	return out, qm, nil
+}
+
+// Delete deletes a host volume from its node and from Nomad.
+func (hv *HostVolumes) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	wm, err := hv.client.delete("/v1/volume/host/"+url.PathEscape(id), nil, nil, w)
+	return wm, err
+}
@@ -533,6 +533,22 @@ func (j *Jobs) Stable(jobID string, version uint64, stable bool,
 	return &resp, wm, nil
 }
 
+// Prefetch asks the nodes where the job's task groups could be placed to
+// fetch their images and artifacts, and waits for them to finish. All task
+// groups are prefetched if taskGroup is empty.
+func (j *Jobs) Prefetch(jobID, taskGroup string, q *WriteOptions) (*JobPrefetchResponse, *WriteMeta, error) {
+	var resp JobPrefetchResponse
+	endpoint := "/v1/job/" + url.PathEscape(jobID) + "/prefetch"
+	if taskGroup != "" {
+		endpoint += "?task_group=" + url.QueryEscape(taskGroup)
+	}
+	wm, err := j.client.put(endpoint, nil, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Services is used to return a list of service registrations associated to the
 // specified jobID.
 func (j *Jobs) Services(jobID string, q *QueryOptions) ([]*ServiceRegistration, *QueryMeta, error) {
@@ -557,6 +573,7 @@ type UpdateStrategy struct {
 	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
 	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
 	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Prefetch         *bool          `mapstructure:"prefetch" hcl:"prefetch,optional"`
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -572,6 +589,7 @@ func DefaultUpdateStrategy() *UpdateStrategy {
 		AutoRevert:       pointerOf(false),
 		Canary:           pointerOf(0),
 		AutoPromote:      pointerOf(false),
+		Prefetch:         pointerOf(false),
 	}
 }
 
@@ -618,6 +636,10 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = pointerOf(*u.AutoPromote)
 	}
 
+	if u.Prefetch != nil {
+		copy.Prefetch = pointerOf(*u.Prefetch)
+	}
+
 	return copy
 }
 
@@ -661,6 +683,10 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = pointerOf(*o.AutoPromote)
 	}
+
+	if o.Prefetch != nil {
+		u.Prefetch = pointerOf(*o.Prefetch)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -701,6 +727,10 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	if u.Prefetch == nil {
+		u.Prefetch = d.Prefetch
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -741,6 +771,10 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if u.Prefetch != nil && *u.Prefetch {
+		return false
+	}
+
 	if u.Canary != nil && *u.Canary != 0 {
 		return false
 	}
@@ -1550,6 +1584,19 @@ type JobStabilityRequest struct {
 	WriteRequest
 }
 
+// JobPrefetchResponse is the response when prefetching a job.
+type JobPrefetchResponse struct {
+	Nodes []*JobPrefetchNodeResult
+	WriteMeta
+}
+
+// JobPrefetchNodeResult is the result of prefetching a task group on a node.
+type JobPrefetchNodeResult struct {
+	NodeID    string
+	TaskGroup string
+	Error     string
+}
+
 // JobStabilityResponse is the response when marking a job as stable.
 type JobStabilityResponse struct {
 	JobModifyIndex uint64
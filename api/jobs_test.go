@@ -309,6 +309,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -349,6 +350,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -490,6 +492,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -530,6 +533,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -555,6 +559,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				Update: &UpdateStrategy{
 					MaxParallel: pointerOf(1),
 					AutoPromote: pointerOf(true),
+					Prefetch:    pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -667,6 +672,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(true),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -707,6 +713,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(true),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(true),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -839,6 +846,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				Periodic: &PeriodicConfig{
 					Enabled:         pointerOf(true),
@@ -866,6 +874,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -882,6 +891,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:     pointerOf(true),
 							Canary:         pointerOf(1),
 							AutoPromote:    pointerOf(true),
+							Prefetch:       pointerOf(false),
 						},
 						Tasks: []*Task{
 							{
@@ -933,6 +943,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -973,6 +984,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(true),
 							Canary:           pointerOf(1),
 							AutoPromote:      pointerOf(true),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1023,6 +1035,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1117,6 +1130,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 				TaskGroups: []*TaskGroup{
 					{
@@ -1157,6 +1171,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1213,6 +1228,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 							AutoRevert:       pointerOf(false),
 							Canary:           pointerOf(0),
 							AutoPromote:      pointerOf(false),
+							Prefetch:         pointerOf(false),
 						},
 						Migrate: DefaultMigrateStrategy(),
 						Tasks: []*Task{
@@ -1296,6 +1312,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 					AutoRevert:       pointerOf(false),
 					Canary:           pointerOf(0),
 					AutoPromote:      pointerOf(false),
+					Prefetch:         pointerOf(false),
 				},
 			},
 		},
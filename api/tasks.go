@@ -74,9 +74,23 @@ type TaskResourceUsage struct {
 type AllocResourceUsage struct {
 	ResourceUsage *ResourceUsage
 	Tasks         map[string]*TaskResourceUsage
+	NetworkStats  *NetworkStats
 	Timestamp     int64
 }
 
+// NetworkStats holds the network throughput of an allocation's network
+// namespace
+type NetworkStats struct {
+	RxBytes       uint64
+	TxBytes       uint64
+	RxPackets     uint64
+	TxPackets     uint64
+	RxDropped     uint64
+	TxDropped     uint64
+	RxBytesPerSec float64
+	TxBytesPerSec float64
+}
+
 // AllocCheckStatus contains the current status of a nomad service discovery check.
 type AllocCheckStatus struct {
 	ID         string
@@ -1114,6 +1128,9 @@ type TaskState struct {
 	// Experimental -  TaskHandle is based on drivers.TaskHandle and used
 	// by remote task drivers to migrate task handles between allocations.
 	TaskHandle *TaskHandle
+
+	PeakMemoryUsage uint64
+	OOMKills        uint64
 }
 
 // Experimental - TaskHandle is based on drivers.TaskHandle and used by remote
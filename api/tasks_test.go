@@ -489,6 +489,7 @@ func TestTaskGroup_Canonicalize_Update(t *testing.T) {
 		Update: &UpdateStrategy{
 			AutoRevert:       pointerOf(false),
 			AutoPromote:      pointerOf(false),
+			Prefetch:         pointerOf(false),
 			Canary:           pointerOf(0),
 			HealthCheck:      pointerOf(""),
 			HealthyDeadline:  pointerOf(time.Duration(0)),
@@ -586,6 +587,7 @@ func TestTaskGroup_Merge_Update(t *testing.T) {
 	must.Eq(t, &UpdateStrategy{
 		AutoRevert:       pointerOf(true),
 		AutoPromote:      pointerOf(false),
+		Prefetch:         pointerOf(false),
 		Canary:           pointerOf(5),
 		HealthCheck:      pointerOf("foo"),
 		HealthyDeadline:  pointerOf(5 * time.Minute),
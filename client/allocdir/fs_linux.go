@@ -6,7 +6,9 @@
 package allocdir
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"syscall"
@@ -107,3 +109,22 @@ func removeSecretDir(dir string) error {
 	}
 	return os.RemoveAll(dir)
 }
+
+// ChownUserNS recursively changes the owner of the local, secrets and tmp
+// directories of a task to the given host UID and GID, so a task running in
+// a user namespace owns the directories it writes to.
+func ChownUserNS(taskDir string, uid, gid int) error {
+	for _, dir := range []string{TaskLocal, TaskSecrets, TmpDirName} {
+		root := filepath.Join(taskDir, dir)
+		err := filepath.WalkDir(root, func(path string, _ fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, uid, gid)
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("Couldn't change owner of %v to (uid: %v, gid: %v): %w", root, uid, gid, err)
+		}
+	}
+	return nil
+}
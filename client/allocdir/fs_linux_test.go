@@ -160,3 +160,39 @@ func TestLinuxUnprivilegedSecretDir(t *testing.T) {
 		t.Fatalf("error removing nonexistent secrets dir %q: %v", secretsDir, err)
 	}
 }
+
+// TestLinuxChownUserNS asserts the directories a task writes to are owned by
+// the given IDs, and that missing directories are skipped.
+func TestLinuxChownUserNS(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	taskDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(taskDir, TaskLocal, "nested"), fileMode777); err != nil {
+		t.Fatalf("error creating local dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(taskDir, TaskLocal, "nested", "file"), nil, fileMode666); err != nil {
+		t.Fatalf("error creating file: %v", err)
+	}
+
+	if err := ChownUserNS(taskDir, 100000, 100001); err != nil {
+		t.Fatalf("error changing owner: %v", err)
+	}
+
+	for _, path := range []string{
+		filepath.Join(taskDir, TaskLocal),
+		filepath.Join(taskDir, TaskLocal, "nested"),
+		filepath.Join(taskDir, TaskLocal, "nested", "file"),
+	} {
+		fi, err := os.Lstat(path)
+		if err != nil {
+			t.Fatalf("error stat'ing %q: %v", path, err)
+		}
+		uid, gid := getOwner(fi)
+		if uid != 100000 || gid != 100001 {
+			t.Fatalf("expected %q to be owned by 100000:100001, got %d:%d", path, uid, gid)
+		}
+	}
+}
@@ -191,7 +191,10 @@ func (t *Tracker) Start() {
 		return
 	case t.consulCheckCount > 0:
 		go t.watchConsulEvents()
-	case t.nomadCheckCount > 0:
+	case t.nomadCheckCount > 0, t.checkStore != nil:
+		// tasks may report driver checks, such as the HEALTHCHECK of a
+		// Docker image, once they run, so the check store is watched even if
+		// the group has no Nomad checks
 		go t.watchNomadEvents()
 	}
 }
@@ -257,7 +260,7 @@ func (t *Tracker) setTaskHealth(healthy, terminal bool) {
 
 	// If we are marked healthy but also require Nomad checks to be healthy and
 	// they are not yet, return, unless the task is terminal.
-	usesNomadChecks := t.useChecks && t.nomadCheckCount > 0
+	usesNomadChecks := t.useChecks && (t.nomadCheckCount > 0 || t.hasDriverChecks())
 	if !terminal && healthy && usesNomadChecks && !t.checksHealthy {
 		return
 	}
@@ -272,6 +275,13 @@ func (t *Tracker) setTaskHealth(healthy, terminal bool) {
 	t.cancelFn()
 }
 
+// hasDriverChecks returns true if the tasks of the allocation report checks
+// through their driver. As driver checks are the only checks in the store of a
+// group without Nomad checks, any stored result is one.
+func (t *Tracker) hasDriverChecks() bool {
+	return t.checkStore != nil && t.consulCheckCount == 0 && len(t.checkStore.List(t.alloc.ID)) > 0
+}
+
 // setCheckHealth is used to mark the checks as either healthy or unhealthy.
 // returns true if health is propagated and no more health monitoring is needed
 //
@@ -536,8 +546,9 @@ OUTER:
 			interpolatedServices = append(interpolatedServices, interpolatedService)
 		}
 
-		// scan for missing or unhealthy consul checks
-		if !evaluateConsulChecks(interpolatedServices, allocReg) {
+		// scan for missing or unhealthy consul checks, and failing driver
+		// checks
+		if !evaluateConsulChecks(interpolatedServices, allocReg) || !t.driverChecksPassing() {
 			t.setCheckHealth(false)
 			passed = false
 		}
@@ -664,25 +675,7 @@ func (t *Tracker) watchNomadEvents() {
 		}
 
 		// scan to see if any checks are failing
-		passing := true
-		for _, result := range results {
-			switch result.Status {
-			case structs.CheckSuccess:
-				continue
-			case structs.CheckFailure:
-				if result.Mode == structs.Readiness {
-					continue
-				}
-				passing = false
-			default:
-				// i.e. pending check; do not consider healthy or ready
-				passing = false
-			}
-
-			if !passing {
-				break // 1+ check is failing; no need to continue
-			}
-		}
+		passing := evaluateNomadChecks(results)
 
 		if !passing {
 			// at least one check is failing, transition to unhealthy
@@ -699,6 +692,36 @@ func (t *Tracker) watchNomadEvents() {
 	}
 }
 
+// evaluateNomadChecks returns true if all the check results are passing,
+// ignoring the failures of readiness checks.
+func evaluateNomadChecks(results map[structs.CheckID]*structs.CheckQueryResult) bool {
+	for _, result := range results {
+		switch result.Status {
+		case structs.CheckSuccess:
+			continue
+		case structs.CheckFailure:
+			if result.Mode == structs.Readiness {
+				continue
+			}
+			return false
+		default:
+			// i.e. pending check; do not consider healthy or ready
+			return false
+		}
+	}
+	return true
+}
+
+// driverChecksPassing returns true if all the checks reported by the drivers
+// of the tasks are passing. Groups with Consul checks have no Nomad checks, so
+// the check store only holds driver checks.
+func (t *Tracker) driverChecksPassing() bool {
+	if t.checkStore == nil {
+		return true
+	}
+	return evaluateNomadChecks(t.checkStore.List(t.alloc.ID))
+}
+
 // taskHealthState captures all known health information about a task. It is
 // largely used to determine if the task has contributed to the allocation being
 // unhealthy.
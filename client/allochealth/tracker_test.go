@@ -408,6 +408,74 @@ func TestTracker_NomadChecks_Unhealthy(t *testing.T) {
 	}
 }
 
+func TestTracker_DriverChecks(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].Migrate.MinHealthyTime = 1 // let's speed things up
+	alloc.Job.TaskGroups[0].Tasks[0].Services = nil
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	logger := testlog.HCLogger(t)
+	b := cstructs.NewAllocBroadcaster(logger)
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Synthesize running alloc and tasks
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	alloc.TaskStates = map[string]*structs.TaskState{
+		task.Name: {
+			State:     structs.TaskStateRunning,
+			StartedAt: time.Now(),
+		},
+	}
+
+	// the group has no Nomad checks, but the driver reports the health of
+	// the task
+	result := &structs.CheckQueryResult{
+		ID:        structs.DriverCheckID(alloc.ID, alloc.TaskGroup, task.Name),
+		Mode:      structs.Healthiness,
+		Status:    structs.CheckPending,
+		Timestamp: time.Now().Unix(),
+		Group:     alloc.Name,
+		Task:      task.Name,
+		Check:     "docker-healthcheck",
+	}
+	checks := checkstore.NewStore(logger, state.NewMemDB(logger))
+	must.NoError(t, checks.Set(alloc.ID, result))
+
+	consul := regmock.NewServiceRegistrationHandler(logger)
+	checkInterval := 10 * time.Millisecond
+	taskEnvBuilder := taskenv.NewBuilder(mock.Node(), alloc, nil, alloc.Job.Region)
+
+	tracker := NewTracker(ctx, logger, alloc, b.Listen(), taskEnvBuilder, consul, checks, time.Millisecond, true)
+	tracker.checkLookupInterval = checkInterval
+	tracker.Start()
+
+	// the allocation isn't healthy while the driver check is pending
+	for i := 0; i < 4; i++ {
+		<-time.After(checkInterval)
+		select {
+		case <-tracker.HealthyCh():
+			t.Fatalf("should not receive on healthy chan with pending driver check")
+		default:
+		}
+	}
+
+	passing := *result
+	passing.Status = structs.CheckSuccess
+	must.NoError(t, checks.Set(alloc.ID, &passing))
+
+	select {
+	case <-time.After(4 * checkInterval):
+		t.Fatalf("timed out while waiting for success")
+	case healthy := <-tracker.HealthyCh():
+		must.True(t, healthy)
+	}
+}
+
 func TestTracker_Checks_PendingPostStop_Healthy(t *testing.T) {
 	ci.Parallel(t)
 
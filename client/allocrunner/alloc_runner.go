@@ -150,6 +150,9 @@ type allocRunner struct {
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
+	// networkStats samples the throughput of the alloc's network namespace
+	networkStats *networkStatsCollector
+
 	// allocBroadcaster sends client allocation updates to all listeners
 	allocBroadcaster *cstructs.AllocBroadcaster
 
@@ -235,6 +238,7 @@ func NewAllocRunner(config *config.AllocRunnerConfig) (interfaces.AllocRunner, e
 		sidsClient:               config.ConsulSI,
 		vaultClientFunc:          config.VaultFunc,
 		tasks:                    make(map[string]*taskrunner.TaskRunner, len(tg.Tasks)),
+		networkStats:             newNetworkStatsCollector(),
 		waitCh:                   make(chan struct{}),
 		destroyCh:                make(chan struct{}),
 		shutdownCh:               make(chan struct{}),
@@ -338,6 +342,7 @@ func (ar *allocRunner) initTaskRunners(tasks []*structs.Task) error {
 			AllocHookResources:  ar.hookResources,
 			WIDMgr:              ar.widmgr,
 			Users:               ar.users,
+			CheckStore:          ar.checkStore,
 		}
 
 		// Create, but do not Run, the task runner
@@ -1297,6 +1302,15 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		}
 	}
 
+	// Network stats are only reported for the whole alloc
+	if taskFilter == "" {
+		netStats, err := ar.networkStats.collect()
+		if err != nil {
+			ar.logger.Debug("failed to collect network stats", "error", err)
+		}
+		astat.NetworkStats = netStats
+	}
+
 	return astat, nil
 }
 
@@ -244,11 +244,18 @@ func (h *checksHook) Update(request *interfaces.RunnerUpdateRequest) error {
 		}
 	}
 
+	// keep the checks reported by the drivers of tasks, which have no observer
+	for _, task := range group.Tasks {
+		next = append(next, structs.DriverCheckID(request.Alloc.ID, request.Alloc.TaskGroup, task.Name))
+	}
+
 	// stop the observers of the checks we are removing
 	remove := h.shim.Difference(request.Alloc.ID, next)
 	for _, id := range remove {
-		h.observers[id].stop()
-		delete(h.observers, id)
+		if o, exists := h.observers[id]; exists {
+			o.stop()
+			delete(h.observers, id)
+		}
 	}
 
 	// remove checks that are no longer part of the allocation
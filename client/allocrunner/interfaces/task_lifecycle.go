@@ -116,6 +116,12 @@ type DriverStats interface {
 	Stats(context.Context, time.Duration) (<-chan *cstructs.TaskResourceUsage, error)
 }
 
+// DriverInspect is the interface implemented by DriverHandles to return the
+// status of the task.
+type DriverInspect interface {
+	Inspect() (*drivers.TaskStatus, error)
+}
+
 type TaskPoststartRequest struct {
 	// Exec hook (may be nil)
 	DriverExec interfaces.ScriptExecutor
@@ -128,6 +134,9 @@ type TaskPoststartRequest struct {
 
 	// Stats collector
 	DriverStats DriverStats
+
+	// Status of the task, as reported by the driver
+	DriverInspect DriverInspect
 }
 type TaskPoststartResponse struct{}
 
@@ -43,6 +43,7 @@ func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsol
 	for _, tr := range a.ar.tasks {
 		tr.SetNetworkIsolation(n)
 	}
+	a.ar.networkStats.setNetworkIsolation(n)
 }
 
 type networkStatusSetter interface {
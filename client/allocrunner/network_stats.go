@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"sync"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// networkStatsCollector samples the interface counters of an allocation's
+// network namespace and computes the throughput between samples.
+type networkStatsCollector struct {
+	// netnsPath is the path of the alloc's network namespace, empty if the
+	// alloc doesn't use group network isolation
+	netnsPath string
+
+	last     *cstructs.NetworkStats
+	lastTime time.Time
+
+	// readStats reads the summed counters of the interfaces in the network
+	// namespace; overridden in tests
+	readStats func(netnsPath string) (*cstructs.NetworkStats, error)
+
+	mu sync.Mutex
+}
+
+func newNetworkStatsCollector() *networkStatsCollector {
+	return &networkStatsCollector{
+		readStats: readNetnsStats,
+	}
+}
+
+// setNetworkIsolation records the network namespace to sample. Only
+// namespaces owned by the group are sampled, as host and task namespaces
+// aren't specific to the allocation.
+func (c *networkStatsCollector) setNetworkIsolation(spec *drivers.NetworkIsolationSpec) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.netnsPath = ""
+	if spec != nil && spec.Mode == drivers.NetIsolationModeGroup {
+		c.netnsPath = spec.Path
+	}
+	c.last = nil
+}
+
+// collect returns the latest network stats of the allocation, or nil if the
+// allocation has no network namespace to sample.
+func (c *networkStatsCollector) collect() (*cstructs.NetworkStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.netnsPath == "" {
+		return nil, nil
+	}
+
+	stats, err := c.readStats(c.netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	// Compute the throughput since the previous sample, carrying the previous
+	// rates forward if the samples are too close together to be meaningful.
+	if c.last != nil {
+		elapsed := now.Sub(c.lastTime).Seconds()
+		if elapsed < 1 {
+			stats.RxBytesPerSec = c.last.RxBytesPerSec
+			stats.TxBytesPerSec = c.last.TxBytesPerSec
+			return stats, nil
+		}
+		if stats.RxBytes >= c.last.RxBytes {
+			stats.RxBytesPerSec = float64(stats.RxBytes-c.last.RxBytes) / elapsed
+		}
+		if stats.TxBytes >= c.last.TxBytes {
+			stats.TxBytesPerSec = float64(stats.TxBytes-c.last.TxBytes) / elapsed
+		}
+	}
+
+	c.last = stats
+	c.lastTime = now
+	copied := *stats
+	return &copied, nil
+}
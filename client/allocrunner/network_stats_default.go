@@ -0,0 +1,17 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build !linux
+
+package allocrunner
+
+import (
+	"errors"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// readNetnsStats is only supported on Linux.
+func readNetnsStats(string) (*cstructs.NetworkStats, error) {
+	return nil, errors.New("network namespace stats are only supported on linux")
+}
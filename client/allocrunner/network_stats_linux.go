@@ -0,0 +1,50 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+//go:build linux
+
+package allocrunner
+
+import (
+	"fmt"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// readNetnsStats sums the counters of the non-loopback interfaces in the
+// network namespace at netnsPath.
+func readNetnsStats(netnsPath string) (*cstructs.NetworkStats, error) {
+	ns, err := netns.GetFromPath(netnsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %w", err)
+	}
+	defer ns.Close()
+
+	handle, err := netlink.NewHandleAt(ns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open netlink handle: %w", err)
+	}
+	defer handle.Close()
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	stats := &cstructs.NetworkStats{}
+	for _, link := range links {
+		attrs := link.Attrs()
+		if attrs.EncapType == "loopback" || attrs.Statistics == nil {
+			continue
+		}
+		stats.RxBytes += attrs.Statistics.RxBytes
+		stats.TxBytes += attrs.Statistics.TxBytes
+		stats.RxPackets += attrs.Statistics.RxPackets
+		stats.TxPackets += attrs.Statistics.TxPackets
+		stats.RxDropped += attrs.Statistics.RxDropped
+		stats.TxDropped += attrs.Statistics.TxDropped
+	}
+	return stats, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package allocrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestNetworkStatsCollector(t *testing.T) {
+	ci.Parallel(t)
+
+	var rx, tx uint64
+	c := newNetworkStatsCollector()
+	c.readStats = func(path string) (*cstructs.NetworkStats, error) {
+		must.Eq(t, "/var/run/netns/alloc", path)
+		return &cstructs.NetworkStats{RxBytes: rx, TxBytes: tx}, nil
+	}
+
+	// Nothing is collected without a group network namespace
+	stats, err := c.collect()
+	must.NoError(t, err)
+	must.Nil(t, stats)
+
+	c.setNetworkIsolation(&drivers.NetworkIsolationSpec{
+		Mode: drivers.NetIsolationModeHost,
+	})
+	stats, err = c.collect()
+	must.NoError(t, err)
+	must.Nil(t, stats)
+
+	c.setNetworkIsolation(&drivers.NetworkIsolationSpec{
+		Mode: drivers.NetIsolationModeGroup,
+		Path: "/var/run/netns/alloc",
+	})
+	rx, tx = 1000, 500
+	stats, err = c.collect()
+	must.NoError(t, err)
+	must.Eq(t, 1000, stats.RxBytes)
+	must.Eq(t, 0, stats.RxBytesPerSec)
+
+	// Pretend the previous sample was taken two seconds ago
+	c.lastTime = c.lastTime.Add(-2 * time.Second)
+	rx, tx = 5000, 2500
+	stats, err = c.collect()
+	must.NoError(t, err)
+	must.Eq(t, 5000, stats.RxBytes)
+	must.Between(t, 1900, stats.RxBytesPerSec, 2000)
+	must.Between(t, 950, stats.TxBytesPerSec, 1000)
+
+	// Samples taken in quick succession carry the previous rate forward
+	rx, tx = 6000, 3000
+	stats, err = c.collect()
+	must.NoError(t, err)
+	must.Eq(t, 6000, stats.RxBytes)
+	must.Between(t, 1900, stats.RxBytesPerSec, 2000)
+}
@@ -40,15 +40,20 @@ type bridgeNetworkConfigurator struct {
 	bridgeName  string
 	hairpinMode bool
 
+	// bandwidthShaping adds the bandwidth CNI plugin to the bridge network so
+	// the alloc's veth is shaped to the mbits requested by the network block
+	bandwidthShaping bool
+
 	logger hclog.Logger
 }
 
-func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, bridgeName, ipRange string, hairpinMode bool, cniPath string, ignorePortMappingHostIP bool, node *structs.Node) (*bridgeNetworkConfigurator, error) {
+func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, bridgeName, ipRange string, hairpinMode, bandwidthShaping bool, cniPath string, ignorePortMappingHostIP bool, node *structs.Node) (*bridgeNetworkConfigurator, error) {
 	b := &bridgeNetworkConfigurator{
-		bridgeName:  bridgeName,
-		allocSubnet: ipRange,
-		hairpinMode: hairpinMode,
-		logger:      log,
+		bridgeName:       bridgeName,
+		allocSubnet:      ipRange,
+		hairpinMode:      hairpinMode,
+		bandwidthShaping: bandwidthShaping,
+		logger:           log,
 	}
 
 	if b.bridgeName == "" {
@@ -76,6 +81,7 @@ func newBridgeNetworkConfigurator(log hclog.Logger, alloc *structs.Allocation, b
 	if err != nil {
 		return nil, err
 	}
+	c.bandwidthShaping = bandwidthShaping
 	b.cni = c
 
 	return b, nil
@@ -158,11 +164,17 @@ func buildNomadBridgeNetConfig(b bridgeNetworkConfigurator, withConsulCNI bool)
 		consulCNI = consulCNIBlock
 	}
 
+	var bandwidth string
+	if b.bandwidthShaping {
+		bandwidth = bandwidthBlock
+	}
+
 	return []byte(fmt.Sprintf(nomadCNIConfigTemplate,
 		b.bridgeName,
 		b.hairpinMode,
 		b.allocSubnet,
 		cniAdminChainName,
+		bandwidth,
 		consulCNI,
 	))
 }
@@ -207,11 +219,17 @@ const nomadCNIConfigTemplate = `{
 			"type": "portmap",
 			"capabilities": {"portMappings": true},
 			"snat": true
-		}%s
+		}%s%s
 	]
 }
 `
 
+const bandwidthBlock = `,
+		{
+			"type": "bandwidth",
+			"capabilities": {"bandwidth": true}
+		}`
+
 const consulCNIBlock = `,
 		{
 			"type": "consul-cni",
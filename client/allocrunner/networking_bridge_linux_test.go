@@ -39,6 +39,14 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 				hairpinMode: true,
 			},
 		},
+		{
+			name: "bandwidth",
+			b: &bridgeNetworkConfigurator{
+				bridgeName:       defaultNomadBridgeName,
+				allocSubnet:      defaultNomadAllocSubnet,
+				bandwidthShaping: true,
+			},
+		},
 		{
 			name:          "consul-cni",
 			withConsulCNI: true,
@@ -61,6 +69,11 @@ func Test_buildNomadBridgeNetConfig(t *testing.T) {
 			} else {
 				must.StrNotContains(t, string(bCfg), "consul-cni")
 			}
+			if tc.b.bandwidthShaping {
+				must.StrContains(t, string(bCfg), `"type": "bandwidth"`)
+			} else {
+				must.StrNotContains(t, string(bCfg), "bandwidth")
+			}
 		})
 	}
 }
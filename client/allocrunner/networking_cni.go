@@ -12,6 +12,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -56,6 +57,10 @@ type cniNetworkConfigurator struct {
 	nodeAttrs               map[string]string
 	nodeMeta                map[string]string
 
+	// bandwidthShaping passes the bandwidth capability arguments to the CNI
+	// plugins so that the alloc's traffic is shaped to its requested mbits
+	bandwidthShaping bool
+
 	rand   *rand.Rand
 	logger log.Logger
 }
@@ -116,6 +121,16 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 
 	portMaps := getPortMapping(alloc, c.ignorePortMappingHostIP)
 
+	setupOpts := []cni.NamespaceOpts{
+		cni.WithCapabilityPortMap(portMaps.ports),
+		cni.WithLabels(cniArgs), // "labels" turn into CNI_ARGS
+	}
+	if c.bandwidthShaping {
+		if bw, ok := getBandwidth(alloc); ok {
+			setupOpts = append(setupOpts, cni.WithCapabilityBandWidth(bw))
+		}
+	}
+
 	tproxyArgs, err := c.setupTransparentProxyArgs(alloc, spec, portMaps)
 	if err != nil {
 		return nil, err
@@ -136,10 +151,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	var res *cni.Result
 	for attempt := 1; ; attempt++ {
 		var err error
-		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path,
-			cni.WithCapabilityPortMap(portMaps.ports),
-			cni.WithLabels(cniArgs), // "labels" turn into CNI_ARGS
-		); err != nil {
+		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, setupOpts...); err != nil {
 			c.logger.Warn("failed to configure network", "error", err, "attempt", attempt)
 			switch attempt {
 			case 1:
@@ -641,3 +653,28 @@ func getPortMapping(alloc *structs.Allocation, ignoreHostIP bool) *portMappings
 	}
 	return mappings
 }
+
+// getBandwidth builds the bandwidth capability arguments for the bandwidth CNI
+// plugin from the mbits allocated to the alloc's shared networks. Both the
+// ingress and egress rates are limited to the requested mbits, with a burst of
+// up to one second of traffic. It returns false if no bandwidth was requested.
+func getBandwidth(alloc *structs.Allocation) (cni.BandWidth, bool) {
+	var mbits int
+	if alloc.AllocatedResources != nil {
+		for _, network := range alloc.AllocatedResources.Shared.Networks {
+			mbits += network.MBits
+		}
+	}
+	if mbits <= 0 {
+		return cni.BandWidth{}, false
+	}
+
+	rate := uint64(mbits) * 1_000_000
+	burst := min(rate, math.MaxInt32)
+	return cni.BandWidth{
+		IngressRate:  rate,
+		IngressBurst: burst,
+		EgressRate:   rate,
+		EgressBurst:  burst,
+	}, true
+}
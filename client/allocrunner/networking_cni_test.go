@@ -7,6 +7,7 @@ package allocrunner
 
 import (
 	"errors"
+	"math"
 	"net"
 	"testing"
 
@@ -438,3 +439,31 @@ func TestCNI_setupTproxyArgs(t *testing.T) {
 	}
 
 }
+
+func TestCNI_getBandwidth(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Networks = nil
+	_, ok := getBandwidth(alloc)
+	must.False(t, ok)
+
+	alloc.AllocatedResources.Shared.Networks = []*structs.NetworkResource{
+		{Mode: "bridge", MBits: 50},
+	}
+	bw, ok := getBandwidth(alloc)
+	must.True(t, ok)
+	must.Eq(t, cni.BandWidth{
+		IngressRate:  50_000_000,
+		IngressBurst: 50_000_000,
+		EgressRate:   50_000_000,
+		EgressBurst:  50_000_000,
+	}, bw)
+
+	// Bursts are capped for very large rates
+	alloc.AllocatedResources.Shared.Networks[0].MBits = 10_000
+	bw, ok = getBandwidth(alloc)
+	must.True(t, ok)
+	must.Eq(t, 10_000_000_000, bw.IngressRate)
+	must.Eq(t, math.MaxInt32, bw.IngressBurst)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// driverChecksHookName is the name of this hook as appears in logs
+	driverChecksHookName = "driver_checks"
+
+	// driverCheckInterval is how often the health of the task is read from
+	// the task driver
+	driverCheckInterval = 5 * time.Second
+)
+
+var _ interfaces.TaskPoststartHook = &driverChecksHook{}
+var _ interfaces.TaskExitedHook = &driverChecksHook{}
+var _ interfaces.TaskStopHook = &driverChecksHook{}
+
+// driverChecksHook stores the health of the task reported by its task driver,
+// such as the state of the HEALTHCHECK of a Docker image, as a Nomad check
+// result. Like the results of Nomad service checks, it's shown by the
+// allocation checks API and counts toward the health of deployments.
+type driverChecksHook struct {
+	alloc      *structs.Allocation
+	task       *structs.Task
+	checkStore checkstore.Shim
+	interval   time.Duration
+	logger     log.Logger
+
+	// cancel stops watching the health of the task, and is set by Poststart
+	cancel context.CancelFunc
+	mu     sync.Mutex
+}
+
+func newDriverChecksHook(alloc *structs.Allocation, task *structs.Task, checkStore checkstore.Shim, logger log.Logger) *driverChecksHook {
+	return &driverChecksHook{
+		alloc:      alloc,
+		task:       task,
+		checkStore: checkStore,
+		interval:   driverCheckInterval,
+		logger:     logger.Named(driverChecksHookName),
+	}
+}
+
+func (*driverChecksHook) Name() string {
+	return driverChecksHookName
+}
+
+func (h *driverChecksHook) Poststart(_ context.Context, req *interfaces.TaskPoststartRequest, _ *interfaces.TaskPoststartResponse) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	// the health of the task is watched until the task exits, so the context
+	// of the request can't be used
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	go h.watch(ctx, req.DriverInspect)
+
+	return nil
+}
+
+func (h *driverChecksHook) Exited(context.Context, *interfaces.TaskExitedRequest, *interfaces.TaskExitedResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *driverChecksHook) Stop(context.Context, *interfaces.TaskStopRequest, *interfaces.TaskStopResponse) error {
+	h.stop()
+	return nil
+}
+
+func (h *driverChecksHook) stop() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+	}
+}
+
+// watch reads the health of the task from the driver on an interval, until
+// the context is canceled. It returns early if the driver doesn't report the
+// health of the task, which doesn't change while the task runs.
+func (h *driverChecksHook) watch(ctx context.Context, inspect interfaces.DriverInspect) {
+	timer, stop := helper.NewSafeTimer(0)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		status, err := inspect.Inspect()
+		switch {
+		case err != nil:
+			h.logger.Debug("failed to inspect task", "error", err)
+		case !h.setResult(status):
+			return
+		}
+		timer.Reset(h.interval)
+	}
+}
+
+// setResult stores the health of the task reported in the task status, and
+// returns false if the status has none.
+func (h *driverChecksHook) setResult(status *drivers.TaskStatus) bool {
+	health, ok := status.DriverAttributes[drivers.TaskHealthStatusAttr]
+	if !ok {
+		return false
+	}
+
+	result := &structs.CheckQueryResult{
+		ID:        structs.DriverCheckID(h.alloc.ID, h.alloc.TaskGroup, h.task.Name),
+		Mode:      structs.Healthiness,
+		Status:    structs.CheckStatus(health),
+		Output:    status.DriverAttributes[drivers.TaskHealthOutputAttr],
+		Timestamp: time.Now().UTC().Unix(),
+		Group:     h.alloc.Name,
+		Task:      h.task.Name,
+		Check:     h.task.Driver + "-healthcheck",
+	}
+	if err := h.checkStore.Set(h.alloc.ID, result); err != nil {
+		h.logger.Error("failed to set task health check status", "error", err)
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package taskrunner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
+	"github.com/hashicorp/nomad/client/state"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+type mockDriverInspect struct {
+	lock   sync.Mutex
+	attrs  map[string]string
+	called int
+}
+
+func (m *mockDriverInspect) set(attrs map[string]string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.attrs = attrs
+}
+
+func (m *mockDriverInspect) calls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.called
+}
+
+func (m *mockDriverInspect) Inspect() (*drivers.TaskStatus, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.called++
+	return &drivers.TaskStatus{DriverAttributes: m.attrs}, nil
+}
+
+func TestDriverChecksHook(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	store := checkstore.NewStore(logger, state.NewMemDB(logger))
+
+	hook := newDriverChecksHook(alloc, task, store, logger)
+	hook.interval = 10 * time.Millisecond
+
+	inspect := &mockDriverInspect{attrs: map[string]string{
+		drivers.TaskHealthStatusAttr: string(structs.CheckPending),
+	}}
+	req := &interfaces.TaskPoststartRequest{DriverInspect: inspect}
+	must.NoError(t, hook.Poststart(context.Background(), req, nil))
+	t.Cleanup(hook.stop)
+
+	id := structs.DriverCheckID(alloc.ID, alloc.TaskGroup, task.Name)
+	status := func(exp structs.CheckStatus) func() bool {
+		return func() bool {
+			result, ok := store.List(alloc.ID)[id]
+			return ok && result.Status == exp
+		}
+	}
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(status(structs.CheckPending)),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	inspect.set(map[string]string{
+		drivers.TaskHealthStatusAttr: string(structs.CheckFailure),
+		drivers.TaskHealthOutputAttr: "connection refused",
+	})
+	must.Wait(t, wait.InitialSuccess(
+		wait.BoolFunc(status(structs.CheckFailure)),
+		wait.Timeout(time.Second),
+		wait.Gap(10*time.Millisecond),
+	))
+
+	result := store.List(alloc.ID)[id]
+	must.Eq(t, structs.Healthiness, result.Mode)
+	must.Eq(t, "connection refused", result.Output)
+	must.Eq(t, alloc.Name, result.Group)
+	must.Eq(t, task.Name, result.Task)
+	must.Eq(t, "exec-healthcheck", result.Check)
+}
+
+func TestDriverChecksHook_noHealth(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	store := checkstore.NewStore(logger, state.NewMemDB(logger))
+
+	hook := newDriverChecksHook(alloc, task, store, logger)
+	hook.interval = time.Millisecond
+
+	// tasks whose driver doesn't report their health are inspected once
+	inspect := &mockDriverInspect{}
+	req := &interfaces.TaskPoststartRequest{DriverInspect: inspect}
+	must.NoError(t, hook.Poststart(context.Background(), req, nil))
+	t.Cleanup(hook.stop)
+
+	time.Sleep(50 * time.Millisecond)
+	must.Eq(t, 1, inspect.calls())
+	must.MapEmpty(t, store.List(alloc.ID))
+}
@@ -60,6 +60,10 @@ func (h *DriverHandle) Stats(ctx context.Context, interval time.Duration) (<-cha
 	return h.driver.TaskStats(ctx, h.taskID, interval)
 }
 
+func (h *DriverHandle) Inspect() (*drivers.TaskStatus, error) {
+	return h.driver.InspectTask(h.taskID)
+}
+
 func (h *DriverHandle) Signal(s string) error {
 	return h.driver.SignalTask(h.taskID, s)
 }
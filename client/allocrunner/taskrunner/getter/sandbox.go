@@ -4,6 +4,12 @@
 package getter
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/config"
 	"github.com/hashicorp/nomad/client/interfaces"
@@ -22,6 +28,9 @@ func New(ac *config.ArtifactConfig, logger hclog.Logger) *Sandbox {
 type Sandbox struct {
 	logger hclog.Logger
 	ac     *config.ArtifactConfig
+
+	// cacheLock serializes moving prefetched artifacts out of the cache
+	cacheLock sync.Mutex
 }
 
 func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) error {
@@ -42,7 +51,69 @@ func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact
 	headers := getHeaders(env, artifact)
 	allocDir, taskDir := getWritableDirs(env)
 
-	params := &parameters{
+	if s.fromCache(cacheKey(source, mode, insecure, headers), destination) {
+		s.logger.Debug("using prefetched artifact", "source", artifact.GetterSource)
+		return nil
+	}
+
+	params := s.parameters(source, destination, mode, insecure, headers)
+	params.AllocDir = allocDir
+	params.TaskDir = taskDir
+
+	if err = s.runCmd(params); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Prefetch downloads the artifact into the cache directory, where the next
+// Get of the same artifact picks it up instead of downloading it again.
+func (s *Sandbox) Prefetch(env interfaces.EnvReplacer, artifact *structs.TaskArtifact) error {
+	if s.ac.CacheDir == "" {
+		return fmt.Errorf("artifact cache is not configured")
+	}
+	s.logger.Debug("prefetch", "source", artifact.GetterSource)
+
+	source, err := getURL(env, artifact)
+	if err != nil {
+		return err
+	}
+
+	mode := getMode(artifact)
+	insecure := isInsecure(artifact)
+	headers := getHeaders(env, artifact)
+
+	cached := filepath.Join(s.ac.CacheDir, cacheKey(source, mode, insecure, headers))
+	if _, err := os.Lstat(cached); err == nil {
+		return nil
+	}
+
+	// download into a scratch directory first so that Get never sees a
+	// partially downloaded artifact
+	if err := os.MkdirAll(s.ac.CacheDir, 0o755); err != nil {
+		return err
+	}
+	scratch, err := os.MkdirTemp(s.ac.CacheDir, "prefetch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(scratch)
+	if err := os.Chmod(scratch, 0o755); err != nil {
+		return err
+	}
+
+	params := s.parameters(source, filepath.Join(scratch, "artifact"), mode, insecure, headers)
+	params.AllocDir = scratch
+	params.TaskDir = scratch
+
+	if err := s.runCmd(params); err != nil {
+		return err
+	}
+	return os.Rename(params.Destination, cached)
+}
+
+func (s *Sandbox) parameters(source, destination string, mode getter.ClientMode, insecure bool, headers map[string][]string) *parameters {
+	return &parameters{
 		// downloader configuration
 		HTTPReadTimeout:               s.ac.HTTPReadTimeout,
 		HTTPMaxBytes:                  s.ac.HTTPMaxBytes,
@@ -62,14 +133,30 @@ func (s *Sandbox) Get(env interfaces.EnvReplacer, artifact *structs.TaskArtifact
 		Source:      source,
 		Destination: destination,
 		Headers:     headers,
+	}
+}
 
-		// task filesystem
-		AllocDir: allocDir,
-		TaskDir:  taskDir,
+// fromCache moves a prefetched artifact to its destination. A prefetched
+// artifact is used once. It returns false if the artifact wasn't prefetched
+// or couldn't be moved, in which case it should be downloaded.
+func (s *Sandbox) fromCache(key, destination string) bool {
+	if s.ac.CacheDir == "" {
+		return false
 	}
 
-	if err = s.runCmd(params); err != nil {
-		return err
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	cached := filepath.Join(s.ac.CacheDir, key)
+	fi, err := os.Lstat(cached)
+	if err != nil {
+		return false
 	}
-	return nil
+
+	if err := moveArtifact(cached, destination, fi.IsDir()); err != nil {
+		s.logger.Warn("failed to use prefetched artifact", "error", err)
+		return false
+	}
+	_ = os.RemoveAll(cached)
+	return true
 }
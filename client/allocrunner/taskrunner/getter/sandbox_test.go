@@ -82,3 +82,40 @@ func TestSandbox_Get_insecure_http(t *testing.T) {
 	err = sbox.Get(env, artifact)
 	must.NoError(t, err)
 }
+
+func TestSandbox_Prefetch(t *testing.T) {
+	testutil.RequireRoot(t)
+	logger := testlog.HCLogger(t)
+
+	ac := artifactConfig(10 * time.Second)
+	ac.CacheDir = t.TempDir()
+	sbox := New(ac, logger)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("prefetched"))
+	}))
+
+	_, taskDir := SetupDir(t)
+	env := noopTaskEnv(taskDir)
+
+	artifact := &structs.TaskArtifact{
+		GetterSource: srv.URL + "/file.txt",
+		RelativeDest: "local/downloads",
+	}
+
+	must.NoError(t, sbox.Prefetch(env, artifact))
+
+	// the artifact is taken from the cache, not downloaded
+	srv.Close()
+	must.NoError(t, sbox.Get(env, artifact))
+
+	b, err := os.ReadFile(filepath.Join(taskDir, "local", "downloads", "file.txt"))
+	must.NoError(t, err)
+	must.Eq(t, "prefetched", string(b))
+
+	// a prefetched artifact is only used once
+	entries, err := os.ReadDir(ac.CacheDir)
+	must.NoError(t, err)
+	must.SliceEmpty(t, entries)
+	must.Error(t, sbox.Get(env, artifact))
+}
@@ -5,6 +5,8 @@ package getter
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -109,6 +111,51 @@ func getWritableDirs(env interfaces.EnvReplacer) (string, string) {
 	return allocDir, taskDir
 }
 
+// cacheKey returns the name of a prefetched artifact in the cache directory.
+// It covers everything that affects what gets downloaded.
+func cacheKey(source string, mode getter.ClientMode, insecure bool, headers map[string][]string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%d\x00%t", source, mode, insecure)
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "\x00%s=%s", k, strings.Join(headers[k], ","))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// moveArtifact moves a downloaded artifact to its destination. Directories
+// are merged into the destination, like the getter does when downloading.
+func moveArtifact(src, destination string, isDir bool) error {
+	if !isDir {
+		if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+			return err
+		}
+		return os.Rename(src, destination)
+	}
+
+	if err := os.MkdirAll(destination, 0o755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		target := filepath.Join(destination, entry.Name())
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.Rename(filepath.Join(src, entry.Name()), target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // environment merges the default minimal environment per-OS with the set of
 // environment variables configured to be inherited from the Client
 func environment(taskDir string, inherit string) []string {
@@ -12,6 +12,7 @@ import (
 	log "github.com/hashicorp/go-hclog"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	bstructs "github.com/hashicorp/nomad/plugins/base/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
 )
 
 const (
@@ -153,3 +154,26 @@ TRY:
 
 	return out, err
 }
+
+func (l *LazyHandle) Inspect() (*drivers.TaskStatus, error) {
+	h, err := l.getHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only retry once
+	first := true
+
+TRY:
+	out, err := h.Inspect()
+	if err == bstructs.ErrPluginShutdown && first {
+		first = false
+
+		h, err = l.refreshHandle()
+		if err == nil {
+			goto TRY
+		}
+	}
+
+	return out, err
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
 	cstate "github.com/hashicorp/nomad/client/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
@@ -281,6 +282,10 @@ type TaskRunner struct {
 	// users manages the pool of dynamic workload users
 	users dynamic.Pool
 
+	// checkStore stores the results of the health checks reported by the
+	// task driver
+	checkStore checkstore.Shim
+
 	// pauser controls whether the task should be run or stopped based on a
 	// schedule. (Enterprise)
 	pauser *pauseGate
@@ -362,6 +367,9 @@ type Config struct {
 
 	// Users manages a pool of dynamic workload users
 	Users dynamic.Pool
+
+	// CheckStore is used to store the results of health checks
+	CheckStore checkstore.Shim
 }
 
 func NewTaskRunner(config *Config) (*TaskRunner, error) {
@@ -425,6 +433,7 @@ func NewTaskRunner(config *Config) (*TaskRunner, error) {
 		wranglers:               config.Wranglers,
 		widmgr:                  config.WIDMgr,
 		users:                   config.Users,
+		checkStore:              config.CheckStore,
 	}
 
 	// Create the logger based on the allocation ID
@@ -1427,6 +1436,11 @@ func (tr *TaskRunner) appendEvent(event *structs.TaskEvent) error {
 		tr.state.LastRestart = time.Unix(0, event.Time)
 	}
 
+	// Track OOM kills so servers can recommend memory right-sizing
+	if event.Type == structs.TaskTerminated && event.Details["oom_killed"] == "true" {
+		tr.state.OOMKills++
+	}
+
 	tr.logger.Info("Task event", "type", event.Type, "msg", event.DisplayMessage, "failed", event.FailsTask)
 
 	// Append event to slice
@@ -1539,10 +1553,28 @@ func (tr *TaskRunner) UpdateStats(ru *cstructs.TaskResourceUsage) {
 	tr.resourceUsage = ru
 	tr.resourceUsageLock.Unlock()
 	if ru != nil {
+		tr.recordPeakMemory(ru)
 		tr.emitStats(ru)
 	}
 }
 
+// recordPeakMemory updates the task state's peak memory usage. The task state
+// isn't marked as updated here; the new peak is sent to the servers with the
+// next task state change, such as the task exiting after an OOM kill.
+func (tr *TaskRunner) recordPeakMemory(ru *cstructs.TaskResourceUsage) {
+	if ru.ResourceUsage.MemoryStats == nil {
+		return
+	}
+	ms := ru.ResourceUsage.MemoryStats
+	peak := max(ms.RSS, ms.Usage, ms.MaxUsage)
+
+	tr.stateLock.Lock()
+	defer tr.stateLock.Unlock()
+	if peak > tr.state.PeakMemoryUsage {
+		tr.state.PeakMemoryUsage = peak
+	}
+}
+
 // TODO Remove Backwardscompat or use tr.Alloc()?
 func (tr *TaskRunner) setGaugeForMemory(ru *cstructs.TaskResourceUsage) {
 	alloc := tr.Alloc()
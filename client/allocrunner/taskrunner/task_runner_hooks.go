@@ -76,6 +76,12 @@ func (tr *TaskRunner) initHooks() {
 		newWranglerHook(tr.wranglers, task.Name, alloc.ID, task.UsesCores(), hookLogger),
 	}
 
+	// If the client stores check results, add the hook storing the health of
+	// the task reported by its driver.
+	if tr.checkStore != nil {
+		tr.runnerHooks = append(tr.runnerHooks, newDriverChecksHook(alloc, task, tr.checkStore, hookLogger))
+	}
+
 	// If the task has a CSI block, add the hook.
 	if task.CSIPluginConfig != nil {
 		tr.runnerHooks = append(tr.runnerHooks, newCSIPluginSupervisorHook(
@@ -366,6 +372,7 @@ func (tr *TaskRunner) poststart() error {
 			DriverExec:    lazyHandle,
 			DriverNetwork: net,
 			DriverStats:   lazyHandle,
+			DriverInspect: lazyHandle,
 			TaskEnv:       tr.envBuilder.Build(),
 		}
 		var resp interfaces.TaskPoststartResponse
@@ -329,6 +329,41 @@ func TestTaskRunner_Stop_ExitCode(t *testing.T) {
 
 }
 
+// TestTaskRunner_MemoryHistory asserts the task state tracks peak memory
+// usage and OOM kills.
+func TestTaskRunner_MemoryHistory(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	conf, cleanup := testTaskRunnerConfig(t, alloc, task.Name, nil)
+	defer cleanup()
+
+	tr, err := NewTaskRunner(conf)
+	must.NoError(t, err)
+
+	usage := func(rss, maxUsage uint64) *cstructs.TaskResourceUsage {
+		return &cstructs.TaskResourceUsage{
+			ResourceUsage: &cstructs.ResourceUsage{
+				MemoryStats: &cstructs.MemoryStats{RSS: rss, MaxUsage: maxUsage},
+				CpuStats:    &cstructs.CpuStats{},
+			},
+		}
+	}
+	tr.UpdateStats(usage(100, 0))
+	tr.UpdateStats(usage(50, 300))
+	tr.UpdateStats(usage(200, 0))
+	must.Eq(t, 300, tr.TaskState().PeakMemoryUsage)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskTerminated).SetOOMKilled(false))
+	must.Eq(t, 0, tr.TaskState().OOMKills)
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskTerminated).SetOOMKilled(true))
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskTerminated).SetOOMKilled(true))
+	must.Eq(t, 2, tr.TaskState().OOMKills)
+}
+
 // TestTaskRunner_Restore_Running asserts restoring a running task does not
 // rerun the task.
 func TestTaskRunner_Restore_Running(t *testing.T) {
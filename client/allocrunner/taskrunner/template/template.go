@@ -533,6 +533,32 @@ func (tm *TaskTemplateManager) onTemplateRendered(handledRenders map[string]time
 		handledRenders[id] = events[id].LastDidRender
 	}
 
+	tm.applyChangeMode(restart, signals, scripts)
+}
+
+// ApplyChangeMode applies the change_mode of the given templates. It is used
+// when templates are updated in place, since the first render of a template
+// manager doesn't trigger any change_mode.
+func (tm *TaskTemplateManager) ApplyChangeMode(tmpls []*structs.Template) {
+	signals := make(map[string]struct{})
+	scripts := []*structs.ChangeScript{}
+	restart := false
+
+	for _, tmpl := range tmpls {
+		switch tmpl.ChangeMode {
+		case structs.TemplateChangeModeSignal:
+			signals[tmpl.ChangeSignal] = struct{}{}
+		case structs.TemplateChangeModeRestart:
+			restart = true
+		case structs.TemplateChangeModeScript:
+			scripts = append(scripts, tmpl.ChangeScript)
+		}
+	}
+
+	tm.applyChangeMode(restart, signals, scripts)
+}
+
+func (tm *TaskTemplateManager) applyChangeMode(restart bool, signals map[string]struct{}, scripts []*structs.ChangeScript) {
 	if restart {
 		tm.config.Lifecycle.Restart(context.Background(),
 			structs.NewTaskEvent(structs.TaskRestartSignal).
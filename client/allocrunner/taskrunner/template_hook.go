@@ -272,8 +272,9 @@ func (h *templateHook) Stop(_ context.Context, req *interfaces.TaskStopRequest,
 	return nil
 }
 
-// Update is used to handle updates to vault and/or nomad tokens.
-func (h *templateHook) Update(_ context.Context, req *interfaces.TaskUpdateRequest, resp *interfaces.TaskUpdateResponse) error {
+// Update is used to handle updates to vault and/or nomad tokens, and to
+// templates that the scheduler updated in place.
+func (h *templateHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, resp *interfaces.TaskUpdateResponse) error {
 	h.managerLock.Lock()
 	defer h.managerLock.Unlock()
 
@@ -282,8 +283,11 @@ func (h *templateHook) Update(_ context.Context, req *interfaces.TaskUpdateReque
 		return nil
 	}
 
-	// neither vault or nomad token has been updated, nothing to do
-	if req.VaultToken == h.vaultToken && req.NomadToken == h.nomadToken {
+	changed := h.updateTemplates(req.Alloc)
+
+	// neither vault or nomad token nor templates have been updated, nothing
+	// to do
+	if req.VaultToken == h.vaultToken && req.NomadToken == h.nomadToken && len(changed) == 0 {
 		return nil
 	} else {
 		h.vaultToken = req.VaultToken
@@ -295,14 +299,65 @@ func (h *templateHook) Update(_ context.Context, req *interfaces.TaskUpdateReque
 	h.templateManager = nil
 
 	// create the new template
-	if _, err := h.newManager(); err != nil {
+	unblock, err := h.newManager()
+	if err != nil {
 		err = fmt.Errorf("failed to build template manager: %v", err)
 		h.logger.Error("failed to build template manager", "error", err)
 		_ = h.config.lifecycle.Kill(context.Background(),
 			structs.NewTaskEvent(structs.TaskKilling).
 				SetFailsTask().
 				SetDisplayMessage(fmt.Sprintf("Template update %v", err)))
+		return nil
+	}
+
+	if len(changed) == 0 {
+		return nil
 	}
 
+	// Wait for the updated templates to render before applying their
+	// change_mode, since the first render of a template manager doesn't
+	// trigger it.
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-unblock:
+	}
+
+	h.logger.Debug("templates updated in place", "count", len(changed))
+	h.config.events.EmitEvent(structs.NewTaskEvent(structs.TaskHookMessage).
+		SetDisplayMessage(fmt.Sprintf("Reloaded %d updated template(s) in place", len(changed))))
+	h.templateManager.ApplyChangeMode(changed)
+
 	return nil
 }
+
+// updateTemplates replaces the managed templates with the ones of the task in
+// the updated allocation, and returns the templates that were changed.
+// Templates can only be updated in place when the scheduler found that their
+// contents changed, so they are compared in order.
+func (h *templateHook) updateTemplates(alloc *structs.Allocation) []*structs.Template {
+	if alloc == nil || alloc.Job == nil || h.task == nil {
+		return nil
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return nil
+	}
+	task := tg.LookupTask(h.task.Name)
+	if task == nil || len(task.Templates) != len(h.config.templates) {
+		return nil
+	}
+
+	var changed []*structs.Template
+	for i, tmpl := range task.Templates {
+		if !tmpl.Equal(h.config.templates[i]) {
+			changed = append(changed, tmpl)
+		}
+	}
+	if len(changed) != 0 {
+		h.config.templates = task.Templates
+		h.config.alloc = alloc
+		h.task = task
+	}
+	return changed
+}
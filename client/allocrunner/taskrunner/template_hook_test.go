@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path"
 	"sync"
 	"testing"
@@ -269,3 +270,63 @@ func Test_templateHook_Prestart_Vault(t *testing.T) {
 		})
 	}
 }
+
+func Test_templateHook_Update_ReloadTemplates(t *testing.T) {
+	ci.Parallel(t)
+
+	clientConfig := config.DefaultConfig()
+	clientConfig.TemplateConfig.DisableSandbox = true
+
+	taskDir := t.TempDir()
+	dest := path.Join(taskDir, "out.txt")
+
+	alloc := mock.MinAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Templates = []*structs.Template{
+		{
+			EmbeddedTmpl: "v1",
+			DestPath:     dest,
+			ChangeMode:   structs.TemplateChangeModeSignal,
+			ChangeSignal: "SIGHUP",
+		},
+	}
+
+	lifecycle := trtesting.NewMockTaskHooks()
+	hook := newTemplateHook(&templateHookConfig{
+		alloc:        alloc,
+		logger:       testlog.HCLogger(t),
+		lifecycle:    lifecycle,
+		events:       &trtesting.MockEmitter{},
+		clientConfig: clientConfig,
+		envBuilder:   taskenv.NewBuilder(mock.Node(), alloc, task, clientConfig.Region),
+		templates:    task.Templates,
+	})
+	t.Cleanup(func() { _ = hook.Stop(context.Background(), nil, nil) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	must.NoError(t, hook.Prestart(ctx, &interfaces.TaskPrestartRequest{
+		Alloc:   alloc,
+		Task:    task,
+		TaskDir: &allocdir.TaskDir{Dir: taskDir},
+	}, &interfaces.TaskPrestartResponse{}))
+
+	out, err := os.ReadFile(dest)
+	must.NoError(t, err)
+	must.Eq(t, "v1", string(out))
+
+	// an update without template changes doesn't signal the task
+	must.NoError(t, hook.Update(ctx, &interfaces.TaskUpdateRequest{Alloc: alloc}, nil))
+	must.SliceEmpty(t, lifecycle.Signals())
+
+	// an in-place update of the template re-renders it and signals the task
+	updated := alloc.Copy()
+	updated.Job.TaskGroups[0].Tasks[0].Templates[0].EmbeddedTmpl = "v2"
+	must.NoError(t, hook.Update(ctx, &interfaces.TaskUpdateRequest{Alloc: updated}, nil))
+
+	out, err = os.ReadFile(dest)
+	must.NoError(t, err)
+	must.Eq(t, "v2", string(out))
+	must.Eq(t, []string{"SIGHUP"}, lifecycle.Signals())
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/fingerprint"
 	"github.com/hashicorp/nomad/client/hoststats"
+	hvm "github.com/hashicorp/nomad/client/hostvolumemanager"
 	cinterfaces "github.com/hashicorp/nomad/client/interfaces"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/client/lib/numalib"
@@ -282,6 +283,9 @@ type Client struct {
 	// csimanager is responsible for managing csi plugins.
 	csimanager csimanager.Manager
 
+	// hostVolumeManager is responsible for managing dynamic host volumes.
+	hostVolumeManager *hvm.HostVolumeManager
+
 	// devicemanger is responsible for managing device plugins.
 	devicemanager devicemanager.Manager
 
@@ -369,6 +373,11 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	// Create the logger
 	logger := cfg.Logger.ResetNamedIntercept("client")
 
+	// Prefetched artifacts are kept in the state dir until a task uses them
+	if cfg.Artifact != nil && cfg.Artifact.CacheDir == "" {
+		cfg.Artifact.CacheDir = filepath.Join(cfg.StateDir, "artifacts")
+	}
+
 	// Create the client
 	c := &Client{
 		config:               cfg,
@@ -431,6 +440,18 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 			},
 		})
 
+	// initialize the host volume manager (needs to happen after init)
+	hostVolumesDir := cfg.HostVolumesDir
+	if hostVolumesDir == "" {
+		hostVolumesDir = filepath.Join(cfg.StateDir, "host_volumes")
+	}
+	c.hostVolumeManager = hvm.NewHostVolumeManager(logger, hvm.Config{
+		PluginDir:      cfg.HostVolumePluginDir,
+		SharedMountDir: hostVolumesDir,
+		StateMgr:       c.stateDB,
+		UpdateNodeVols: c.updateNodeFromHostVolume,
+	})
+
 	// Setup the clients RPC server
 	c.setupClientRpc(rpcs)
 
@@ -1560,6 +1581,23 @@ func (c *Client) setupNode() error {
 			}
 		}
 	}
+
+	// Merge dynamic host volumes created through the API
+	dynamicVols, err := c.hostVolumeManager.RestoreFromState()
+	if err != nil {
+		return fmt.Errorf("error restoring dynamic host volumes: %w", err)
+	}
+	if len(dynamicVols) != 0 && node.HostVolumes == nil {
+		node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig, len(dynamicVols))
+	}
+	for name, vol := range dynamicVols {
+		if _, ok := node.HostVolumes[name]; ok {
+			c.logger.Warn("dynamic host volume shadowed by volume in client config", "name", name, "id", vol.ID)
+			continue
+		}
+		node.HostVolumes[name] = vol
+	}
+
 	if node.HostNetworks == nil {
 		if l := len(newConfig.HostNetworks); l != 0 {
 			node.HostNetworks = make(map[string]*structs.ClientHostNetworkConfig, l)
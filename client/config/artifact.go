@@ -29,6 +29,10 @@ type ArtifactConfig struct {
 	DisableFilesystemIsolation    bool
 	FilesystemIsolationExtraPaths []string
 	SetEnvironmentVariables       string
+
+	// CacheDir is where prefetched artifacts are kept until a task uses
+	// them. It is set by the client rather than the agent's config file.
+	CacheDir string
 }
 
 // ArtifactConfigFromAgent creates a new internal readonly copy of the client
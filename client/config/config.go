@@ -51,6 +51,7 @@ var (
 		"exec",
 		"qemu",
 		"java",
+		"oci",
 	}, ",")
 
 	// DefaultChrootEnv is a mapping of directories on the host OS to attempt to embed inside each
@@ -107,6 +108,13 @@ type Config struct {
 	// should be owned  by root with file mode 0o755.
 	AllocMountsDir string
 
+	// HostVolumesDir is where the built-in mkdir host volume plugin creates
+	// dynamic host volumes.
+	HostVolumesDir string
+
+	// HostVolumePluginDir is where host volume plugin executables are found.
+	HostVolumePluginDir string
+
 	// Logger provides a logger to the client
 	Logger log.InterceptLogger
 
@@ -286,6 +294,11 @@ type Config struct {
 	// internal bridge network
 	BridgeNetworkHairpinMode bool
 
+	// BridgeNetworkBandwidthShaping is whether or not to shape the ingress and
+	// egress traffic of allocations in bridge networking mode to the mbits
+	// requested by their network block
+	BridgeNetworkBandwidthShaping bool
+
 	// BridgeNetworkAllocSubnet is the IP subnet to use for address allocation
 	// for allocations in bridge networking mode. Subnet must be in CIDR
 	// notation
@@ -43,6 +43,8 @@ func (f *BridgeFingerprint) Fingerprint(req *FingerprintRequest, resp *Fingerpri
 
 	resp.AddAttribute("nomad.bridge.hairpin_mode",
 		strconv.FormatBool(req.Config.BridgeNetworkHairpinMode))
+	resp.AddAttribute("nomad.bridge.bandwidth_shaping",
+		strconv.FormatBool(req.Config.BridgeNetworkBandwidthShaping))
 
 	resp.Detected = true
 	return nil
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolumePlugin provisions and deletes dynamic host volumes on the client.
+type HostVolumePlugin interface {
+	Create(context.Context, *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error)
+	Delete(context.Context, *cstructs.ClientHostVolumeDeleteRequest) error
+}
+
+// HostVolumePluginCreateResponse is the result of provisioning a volume.
+type HostVolumePluginCreateResponse struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"bytes"`
+}
+
+// HostVolumePluginMkdir is the built-in plugin that creates a directory in
+// the client's host volumes directory.
+type HostVolumePluginMkdir struct {
+	ID         string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginMkdir) Create(_ context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With("operation", "create", "volume_id", req.ID, "path", path)
+	log.Debug("running plugin")
+
+	if err := os.MkdirAll(path, 0o700); err != nil {
+		log.Error("error creating directory", "error", err)
+		return nil, err
+	}
+
+	// the mkdir plugin can't enforce a capacity, so it reports none
+	return &HostVolumePluginCreateResponse{Path: path}, nil
+}
+
+func (p *HostVolumePluginMkdir) Delete(_ context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With("operation", "delete", "volume_id", req.ID, "path", path)
+	log.Debug("running plugin")
+
+	if err := os.RemoveAll(path); err != nil {
+		log.Error("error deleting directory", "error", err)
+		return err
+	}
+	return nil
+}
+
+// HostVolumePluginExternal runs an executable from the host volume plugin
+// directory. The executable is called with "create" or "delete" as its only
+// argument and receives the volume in its environment. On create it must
+// write a JSON HostVolumePluginCreateResponse to stdout.
+type HostVolumePluginExternal struct {
+	ID         string
+	Executable string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+// NewHostVolumePluginExternal returns the external plugin with the given ID,
+// or an error if its executable can't be found in pluginDir.
+func NewHostVolumePluginExternal(log hclog.Logger, id, pluginDir, targetPath string) (*HostVolumePluginExternal, error) {
+	if id == "" || filepath.Base(id) != id {
+		return nil, fmt.Errorf("invalid plugin ID %q", id)
+	}
+
+	executable := filepath.Join(pluginDir, id)
+	fi, err := os.Stat(executable)
+	if err != nil {
+		return nil, fmt.Errorf("could not find plugin %q: %w", id, err)
+	}
+	if fi.IsDir() || fi.Mode()&0o111 == 0 {
+		return nil, fmt.Errorf("plugin %q is not executable", id)
+	}
+
+	return &HostVolumePluginExternal{
+		ID:         id,
+		Executable: executable,
+		TargetPath: targetPath,
+		log:        log.With("plugin_id", id),
+	}, nil
+}
+
+func (p *HostVolumePluginExternal) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	env := []string{
+		"HOST_PATH=" + path,
+		"NODE_ID=" + req.NodeID,
+		"VOLUME_NAME=" + req.Name,
+		"CAPACITY_MIN_BYTES=" + strconv.FormatInt(req.RequestedCapacityMinBytes, 10),
+		"CAPACITY_MAX_BYTES=" + strconv.FormatInt(req.RequestedCapacityMaxBytes, 10),
+		"PARAMETERS=" + string(params),
+	}
+
+	stdout, err := p.runPlugin(ctx, "create", req.ID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp HostVolumePluginCreateResponse
+	if err := json.Unmarshal(stdout, &resp); err != nil {
+		return nil, fmt.Errorf("error parsing plugin output: %w", err)
+	}
+	if resp.Path == "" {
+		return nil, errors.New("plugin did not return a path")
+	}
+	return &resp, nil
+}
+
+func (p *HostVolumePluginExternal) Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+
+	env := []string{
+		"HOST_PATH=" + req.HostPath,
+		"NODE_ID=" + req.NodeID,
+		"VOLUME_NAME=" + req.Name,
+		"PARAMETERS=" + string(params),
+	}
+
+	_, err = p.runPlugin(ctx, "delete", req.ID, env)
+	return err
+}
+
+func (p *HostVolumePluginExternal) runPlugin(ctx context.Context, op, volID string, env []string) ([]byte, error) {
+	log := p.log.With("operation", op, "volume_id", volID)
+	log.Debug("running plugin")
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Executable, op)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		log.Error("error running plugin", "error", err, "stderr", stderr.String())
+		return nil, fmt.Errorf("error running plugin %q: %w: %s", p.ID, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
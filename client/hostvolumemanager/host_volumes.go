@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var ErrPluginNotExists = errors.New("no such plugin")
+
+// HostVolumeStateManager persists the dynamic host volumes of the client.
+type HostVolumeStateManager interface {
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+	DeleteDynamicHostVolume(string) error
+}
+
+// UpdateVolumeMap is called to add (vol != nil) or remove (vol == nil) a
+// dynamic host volume from the node's fingerprint.
+type UpdateVolumeMap func(name string, vol *structs.ClientHostVolumeConfig)
+
+// Config is the configuration of a HostVolumeManager.
+type Config struct {
+	// PluginDir is where external host volume plugins are found.
+	PluginDir string
+
+	// SharedMountDir is where volumes are created by the plugins.
+	SharedMountDir string
+
+	// StateMgr persists the created volumes.
+	StateMgr HostVolumeStateManager
+
+	// UpdateNodeVols updates the node's fingerprint.
+	UpdateNodeVols UpdateVolumeMap
+}
+
+// HostVolumeManager provisions and deletes dynamic host volumes with host
+// volume plugins, and keeps the node's fingerprint up to date.
+type HostVolumeManager struct {
+	pluginDir      string
+	sharedMountDir string
+	stateMgr       HostVolumeStateManager
+	updateNodeVols UpdateVolumeMap
+	log            hclog.Logger
+
+	// volNames guards against two volumes with the same name on the node
+	volNames map[string]string
+	volLock  sync.Mutex
+}
+
+func NewHostVolumeManager(logger hclog.Logger, config Config) *HostVolumeManager {
+	return &HostVolumeManager{
+		pluginDir:      config.PluginDir,
+		sharedMountDir: config.SharedMountDir,
+		stateMgr:       config.StateMgr,
+		updateNodeVols: config.UpdateNodeVols,
+		log:            logger.Named("host_volume_manager"),
+		volNames:       map[string]string{},
+	}
+}
+
+// RestoreFromState returns the volumes persisted in client state so they can
+// be merged into the node's fingerprint at startup.
+func (hvm *HostVolumeManager) RestoreFromState() (map[string]*structs.ClientHostVolumeConfig, error) {
+	vols, err := hvm.stateMgr.GetDynamicHostVolumes()
+	if err != nil {
+		return nil, err
+	}
+
+	hvm.volLock.Lock()
+	defer hvm.volLock.Unlock()
+
+	volumes := make(map[string]*structs.ClientHostVolumeConfig, len(vols))
+	for _, vol := range vols {
+		hvm.volNames[vol.Name] = vol.ID
+		volumes[vol.Name] = genVolConfig(vol)
+	}
+	return volumes, nil
+}
+
+func (hvm *HostVolumeManager) getPlugin(id string) (HostVolumePlugin, error) {
+	if id == structs.HostVolumePluginMkdir {
+		return &HostVolumePluginMkdir{
+			ID:         id,
+			TargetPath: hvm.sharedMountDir,
+			log:        hvm.log.With("plugin_id", id),
+		}, nil
+	}
+
+	plug, err := NewHostVolumePluginExternal(hvm.log, id, hvm.pluginDir, hvm.sharedMountDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPluginNotExists, err)
+	}
+	return plug, nil
+}
+
+// Create provisions a volume with its plugin, persists it, and adds it to
+// the node's fingerprint.
+func (hvm *HostVolumeManager) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*cstructs.ClientHostVolumeCreateResponse, error) {
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	hvm.volLock.Lock()
+	defer hvm.volLock.Unlock()
+
+	if id, ok := hvm.volNames[req.Name]; ok && id != req.ID {
+		return nil, fmt.Errorf("volume with name %q already exists on node", req.Name)
+	}
+
+	pluginResp, err := plug.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	volState := &cstructs.HostVolumeState{
+		ID:            req.ID,
+		Name:          req.Name,
+		PluginID:      req.PluginID,
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.SizeBytes,
+		Parameters:    req.Parameters,
+	}
+	if err := hvm.stateMgr.PutDynamicHostVolume(volState); err != nil {
+		// if we can't save state, roll back by deleting the volume
+		delErr := plug.Delete(ctx, &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         req.ID,
+			Name:       req.Name,
+			PluginID:   req.PluginID,
+			NodeID:     req.NodeID,
+			HostPath:   pluginResp.Path,
+			Parameters: req.Parameters,
+		})
+		if delErr != nil {
+			hvm.log.Warn("error deleting volume after state store failure",
+				"volume_id", req.ID, "error", delErr)
+			err = errors.Join(err, delErr)
+		}
+		return nil, err
+	}
+
+	hvm.volNames[req.Name] = req.ID
+	hvm.updateNodeVols(req.Name, genVolConfig(volState))
+
+	return &cstructs.ClientHostVolumeCreateResponse{
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.SizeBytes,
+	}, nil
+}
+
+// Delete deletes a volume with its plugin, removes it from the node's
+// fingerprint, and forgets it.
+func (hvm *HostVolumeManager) Delete(ctx context.Context,
+	req *cstructs.ClientHostVolumeDeleteRequest) (*cstructs.ClientHostVolumeDeleteResponse, error) {
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	hvm.volLock.Lock()
+	defer hvm.volLock.Unlock()
+
+	if err := plug.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if hvm.volNames[req.Name] == req.ID {
+		delete(hvm.volNames, req.Name)
+		hvm.updateNodeVols(req.Name, nil)
+	}
+
+	if err := hvm.stateMgr.DeleteDynamicHostVolume(req.ID); err != nil {
+		hvm.log.Error("failed to delete volume in state", "volume_id", req.ID, "error", err)
+		return nil, err
+	}
+
+	return &cstructs.ClientHostVolumeDeleteResponse{}, nil
+}
+
+// genVolConfig returns the fingerprint of a dynamic host volume.
+func genVolConfig(vol *cstructs.HostVolumeState) *structs.ClientHostVolumeConfig {
+	return &structs.ClientHostVolumeConfig{
+		Name:     vol.Name,
+		ID:       vol.ID,
+		Path:     vol.HostPath,
+		ReadOnly: false,
+	}
+}
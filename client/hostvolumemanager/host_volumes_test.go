@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package hostvolumemanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	cstate "github.com/hashicorp/nomad/client/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestHostVolumeManager_Mkdir(t *testing.T) {
+	ci.Parallel(t)
+
+	logger := testlog.HCLogger(t)
+	db := cstate.NewMemDB(logger)
+	vols := map[string]*structs.ClientHostVolumeConfig{}
+	hvm := NewHostVolumeManager(logger, Config{
+		PluginDir:      t.TempDir(),
+		SharedMountDir: t.TempDir(),
+		StateMgr:       db,
+		UpdateNodeVols: func(name string, vol *structs.ClientHostVolumeConfig) {
+			if vol == nil {
+				delete(vols, name)
+				return
+			}
+			vols[name] = vol
+		},
+	})
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       uuid.Generate(),
+		Name:     "example",
+		PluginID: structs.HostVolumePluginMkdir,
+	}
+	resp, err := hvm.Create(context.Background(), req)
+	must.NoError(t, err)
+	must.DirExists(t, resp.HostPath)
+	must.MapContainsKey(t, vols, "example")
+	must.Eq(t, req.ID, vols["example"].ID)
+
+	// the volume is persisted so it can be restored
+	restored, err := hvm.RestoreFromState()
+	must.NoError(t, err)
+	must.Eq(t, resp.HostPath, restored["example"].Path)
+
+	// another volume with the same name is rejected
+	dupe := *req
+	dupe.ID = uuid.Generate()
+	_, err = hvm.Create(context.Background(), &dupe)
+	must.ErrorContains(t, err, "already exists")
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       req.ID,
+		Name:     req.Name,
+		PluginID: req.PluginID,
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+	must.MapNotContainsKey(t, vols, "example")
+
+	persisted, err := db.GetDynamicHostVolumes()
+	must.NoError(t, err)
+	must.Len(t, 0, persisted)
+}
+
+func TestHostVolumeManager_External(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugin is a shell script")
+	}
+
+	pluginDir := t.TempDir()
+	script := `#!/bin/sh
+set -e
+case "$1" in
+  create)
+    mkdir -p "$HOST_PATH"
+    echo "{\"path\": \"$HOST_PATH\", \"bytes\": $CAPACITY_MIN_BYTES}"
+    ;;
+  delete)
+    rm -rf "$HOST_PATH"
+    ;;
+esac
+`
+	must.NoError(t, os.WriteFile(filepath.Join(pluginDir, "test-plugin"), []byte(script), 0o755))
+
+	logger := testlog.HCLogger(t)
+	hvm := NewHostVolumeManager(logger, Config{
+		PluginDir:      pluginDir,
+		SharedMountDir: t.TempDir(),
+		StateMgr:       cstate.NewMemDB(logger),
+		UpdateNodeVols: func(string, *structs.ClientHostVolumeConfig) {},
+	})
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        uuid.Generate(),
+		Name:                      "example",
+		PluginID:                  "test-plugin",
+		RequestedCapacityMinBytes: 1024,
+	}
+	resp, err := hvm.Create(context.Background(), req)
+	must.NoError(t, err)
+	must.DirExists(t, resp.HostPath)
+	must.Eq(t, 1024, resp.CapacityBytes)
+
+	_, err = hvm.Delete(context.Background(), &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       req.ID,
+		Name:     req.Name,
+		PluginID: req.PluginID,
+		HostPath: resp.HostPath,
+	})
+	must.NoError(t, err)
+	_, err = os.Stat(resp.HostPath)
+	must.True(t, os.IsNotExist(err))
+
+	// unknown plugins and plugin IDs that escape the plugin dir are rejected
+	req.PluginID = "../test-plugin"
+	_, err = hvm.Create(context.Background(), req)
+	must.ErrorIs(t, err, ErrPluginNotExists)
+}
@@ -42,6 +42,10 @@ type EnvReplacer interface {
 type ArtifactGetter interface {
 	// Get artifact and put it in the task directory.
 	Get(EnvReplacer, *structs.TaskArtifact) error
+
+	// Prefetch artifact into the cache so a later Get doesn't have to
+	// download it.
+	Prefetch(EnvReplacer, *structs.TaskArtifact) error
 }
 
 // ProcessWranglers is an interface satisfied by the proclib package.
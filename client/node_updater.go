@@ -93,6 +93,22 @@ SEND_BATCH:
 	close(c.fpInitialized)
 }
 
+// updateNodeFromHostVolume implements hostvolumemanager.UpdateVolumeMap and
+// adds or removes a dynamic host volume from the node fingerprint.
+func (c *Client) updateNodeFromHostVolume(name string, vol *structs.ClientHostVolumeConfig) {
+	c.UpdateNode(func(node *structs.Node) {
+		if vol == nil {
+			delete(node.HostVolumes, name)
+			return
+		}
+		if node.HostVolumes == nil {
+			node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig)
+		}
+		node.HostVolumes[name] = vol
+	})
+	c.updateNode()
+}
+
 // updateNodeFromCSI receives a CSIInfo struct for the plugin and updates the
 // node accordingly
 func (c *Client) updateNodeFromCSI(name string, info *structs.CSIInfo) {
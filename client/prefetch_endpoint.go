@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	multierror "github.com/hashicorp/go-multierror"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/client/taskenv"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclspecutils"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// Prefetch is the client endpoint used by the servers to fetch the images and
+// artifacts of task groups before they are placed on the node.
+type Prefetch struct {
+	c *Client
+}
+
+func newPrefetchEndpoint(c *Client) *Prefetch {
+	return &Prefetch{c: c}
+}
+
+// TaskGroup fetches the artifacts of each task of the task group, and its
+// image if the task driver supports prefetching.
+func (p *Prefetch) TaskGroup(req *cstructs.ClientPrefetchRequest, resp *cstructs.ClientPrefetchResponse) error {
+	defer metrics.MeasureSince([]string{"client", "prefetch", "task_group"}, time.Now())
+
+	if req.Job == nil {
+		return errors.New("missing job")
+	}
+	tg := req.Job.LookupTaskGroup(req.TaskGroup)
+	if tg == nil {
+		return fmt.Errorf("job %q has no task group %q", req.Job.ID, req.TaskGroup)
+	}
+
+	// The task group isn't placed yet, so its tasks are interpolated for a
+	// placeholder allocation on this node.
+	node := p.c.Node()
+	alloc := &structs.Allocation{
+		ID:        uuid.Generate(),
+		Namespace: req.Job.Namespace,
+		Name:      structs.AllocName(req.Job.ID, tg.Name, 0),
+		JobID:     req.Job.ID,
+		Job:       req.Job,
+		TaskGroup: tg.Name,
+		NodeID:    node.ID,
+		NodeName:  node.Name,
+	}
+
+	var mErr *multierror.Error
+	for _, task := range tg.Tasks {
+		if err := p.prefetchTask(node, alloc, task); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("task %q: %w", task.Name, err))
+		}
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		p.c.logger.Error("failed to prefetch task group", "job", req.Job.ID, "task_group", tg.Name, "error", err)
+		return err
+	}
+
+	p.c.logger.Info("prefetched task group", "job", req.Job.ID, "task_group", tg.Name)
+	return nil
+}
+
+func (p *Prefetch) prefetchTask(node *structs.Node, alloc *structs.Allocation, task *structs.Task) error {
+	env := taskenv.NewBuilder(node, alloc, task, p.c.Region()).Build()
+
+	for _, artifact := range task.Artifacts {
+		if err := p.c.getter.Prefetch(env, artifact); err != nil {
+			return fmt.Errorf("failed to prefetch artifact %q: %w", artifact.GetterSource, err)
+		}
+	}
+
+	driver, err := p.c.drivermanager.Dispense(task.Driver)
+	if err != nil {
+		return err
+	}
+	prefetcher, ok := driver.(drivers.DriverPrefetcher)
+	if !ok {
+		return nil
+	}
+
+	schema, err := driver.TaskConfigSchema()
+	if err != nil {
+		return err
+	}
+	spec, diag := hclspecutils.Convert(schema)
+	if diag.HasErrors() {
+		return multierror.Append(errors.New("failed to convert task schema"), diag.Errs()...)
+	}
+
+	vars, _, err := env.AllValues()
+	if err != nil {
+		return fmt.Errorf("error building environment variables: %w", err)
+	}
+	val, diag, diagErrs := hclutils.ParseHclInterface(task.Config, spec, vars)
+	if diag.HasErrors() {
+		return multierror.Append(errors.New("failed to parse config: "), diagErrs...)
+	}
+
+	taskConfig := &drivers.TaskConfig{
+		ID:            fmt.Sprintf("%s/%s/prefetch", alloc.ID, task.Name),
+		Name:          task.Name,
+		JobName:       alloc.Job.Name,
+		JobID:         alloc.Job.ID,
+		TaskGroupName: alloc.TaskGroup,
+		Namespace:     alloc.Namespace,
+		NodeName:      alloc.NodeName,
+		NodeID:        alloc.NodeID,
+		ParentJobID:   alloc.Job.ParentID,
+		AllocID:       alloc.ID,
+		Env:           env.Map(),
+		User:          task.User,
+	}
+	if err := taskConfig.EncodeDriverConfig(val); err != nil {
+		return fmt.Errorf("failed to encode driver config: %w", err)
+	}
+
+	return prefetcher.PrefetchTask(taskConfig)
+}
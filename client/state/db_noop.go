@@ -145,6 +145,18 @@ func (n NoopDB) GetNodeRegistration() (*cstructs.NodeRegistration, error) {
 	return nil, nil
 }
 
+func (n NoopDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	return nil
+}
+
+func (n NoopDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	return nil, nil
+}
+
+func (n NoopDB) DeleteDynamicHostVolume(id string) error {
+	return nil
+}
+
 func (n NoopDB) Close() error {
 	return nil
 }
@@ -137,6 +137,17 @@ type StateDB interface {
 	PutNodeRegistration(*cstructs.NodeRegistration) error
 	GetNodeRegistration() (*cstructs.NodeRegistration, error)
 
+	// PutDynamicHostVolume sets the state of a dynamic host volume created on
+	// this Client.
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+
+	// GetDynamicHostVolumes is used to restore the dynamic host volumes
+	// created on this Client.
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+
+	// DeleteDynamicHostVolume removes the state of a dynamic host volume.
+	DeleteDynamicHostVolume(id string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+// ClientHostVolumeCreateRequest is the RPC made from the server to a Nomad
+// client to provision a dynamic host volume with a host volume plugin.
+type ClientHostVolumeCreateRequest struct {
+	ID       string // ID of the volume, generated by the server (required)
+	Name     string // Name the volume is fingerprinted as (required)
+	PluginID string // ID of the host volume plugin (required)
+	NodeID   string // ID of the Nomad client targeted
+
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// Parameters are opaque key/values passed to the plugin.
+	Parameters map[string]string
+}
+
+// ClientHostVolumeCreateResponse is the response to a host volume create
+// request.
+type ClientHostVolumeCreateResponse struct {
+	// HostPath is the path of the volume on the client.
+	HostPath string
+
+	// CapacityBytes is the capacity of the volume reported by the plugin,
+	// or 0 if the plugin doesn't report a capacity.
+	CapacityBytes int64
+}
+
+// ClientHostVolumeDeleteRequest is the RPC made from the server to a Nomad
+// client to delete a dynamic host volume.
+type ClientHostVolumeDeleteRequest struct {
+	ID       string // ID of the volume (required)
+	Name     string // Name the volume is fingerprinted as (required)
+	PluginID string // ID of the host volume plugin (required)
+	NodeID   string // ID of the Nomad client targeted
+	HostPath string // Path of the volume on the client
+
+	// Parameters are opaque key/values passed to the plugin.
+	Parameters map[string]string
+}
+
+// ClientHostVolumeDeleteResponse is the response to a host volume delete
+// request.
+type ClientHostVolumeDeleteResponse struct{}
+
+// HostVolumeState is the client's persisted state of a dynamic host volume,
+// used to restore the volume into the node's fingerprint after a restart.
+type HostVolumeState struct {
+	ID            string
+	Name          string
+	PluginID      string
+	HostPath      string
+	CapacityBytes int64
+	Parameters    map[string]string
+}
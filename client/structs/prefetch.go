@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import "github.com/hashicorp/nomad/nomad/structs"
+
+// ClientPrefetchRequest is the RPC made from the server to a Nomad client to
+// fetch the images and artifacts of a task group before it's placed on the
+// node.
+type ClientPrefetchRequest struct {
+	NodeID    string       // ID of the Nomad client targeted
+	Job       *structs.Job // Job of the task group (required)
+	TaskGroup string       // Name of the task group (required)
+}
+
+// ClientPrefetchResponse is the response to a prefetch request.
+type ClientPrefetchResponse struct{}
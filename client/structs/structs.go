@@ -317,10 +317,30 @@ type AllocResourceUsage struct {
 	// Tasks contains the resource usage of each task
 	Tasks map[string]*TaskResourceUsage
 
+	// NetworkStats is the throughput of the allocation's network namespace.
+	// It is only set for allocations with group network isolation.
+	NetworkStats *NetworkStats
+
 	// The max timestamp of all the Tasks
 	Timestamp int64
 }
 
+// NetworkStats holds the network throughput of an allocation's network
+// namespace, summed across its interfaces.
+type NetworkStats struct {
+	RxBytes   uint64
+	TxBytes   uint64
+	RxPackets uint64
+	TxPackets uint64
+	RxDropped uint64
+	TxDropped uint64
+
+	// RxBytesPerSec and TxBytesPerSec are the throughput since the previous
+	// sample was taken
+	RxBytesPerSec float64
+	TxBytesPerSec float64
+}
+
 // joinStringSet takes two slices of strings and joins them
 func joinStringSet(s1, s2 []string) []string {
 	lookup := make(map[string]struct{}, len(s1))
@@ -226,6 +226,13 @@ type ClientConfig struct {
 	// AllocMountsDir is the directory for storing mounts into allocation data
 	AllocMountsDir string `hcl:"alloc_mounts_dir"`
 
+	// HostVolumesDir is the directory in which dynamic host volumes are
+	// created by the built-in mkdir plugin
+	HostVolumesDir string `hcl:"host_volumes_dir"`
+
+	// HostVolumePluginDir is the directory searched for host volume plugins
+	HostVolumePluginDir string `hcl:"host_volume_plugin_dir"`
+
 	// Servers is a list of known server addresses. These are as "host:port"
 	Servers []string `hcl:"servers"`
 
@@ -358,6 +365,11 @@ type ClientConfig struct {
 	// internal bridge network
 	BridgeNetworkHairpinMode bool `hcl:"bridge_network_hairpin_mode"`
 
+	// BridgeNetworkBandwidthShaping is whether or not to limit the ingress and
+	// egress bandwidth of allocations using the bridge network mode to the
+	// mbits requested by their network block
+	BridgeNetworkBandwidthShaping bool `hcl:"bridge_network_bandwidth_shaping"`
+
 	// HostNetworks describes the different host networks available to the host
 	// if the host uses multiple interfaces
 	HostNetworks []*structs.ClientHostNetworkConfig `hcl:"host_network"`
@@ -2256,6 +2268,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.AllocMountsDir != "" {
 		result.AllocMountsDir = b.AllocMountsDir
 	}
+	if b.HostVolumesDir != "" {
+		result.HostVolumesDir = b.HostVolumesDir
+	}
+	if b.HostVolumePluginDir != "" {
+		result.HostVolumePluginDir = b.HostVolumePluginDir
+	}
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
@@ -2389,6 +2407,10 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.BridgeNetworkHairpinMode = true
 	}
 
+	if b.BridgeNetworkBandwidthShaping {
+		result.BridgeNetworkBandwidthShaping = true
+	}
+
 	result.HostNetworks = a.HostNetworks
 
 	if len(b.HostNetworks) != 0 {
@@ -20,14 +20,17 @@ func (s *HTTPServer) CSIVolumesRequest(resp http.ResponseWriter, req *http.Reque
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
 
-	// Type filters volume lists to a specific type. When support for non-CSI volumes is
-	// introduced, we'll need to dispatch here
+	// Type filters volume lists to a specific type
 	query := req.URL.Query()
 	qtype, ok := query["type"]
 	if !ok {
 		return []*structs.CSIVolListStub{}, nil
 	}
-	if qtype[0] != "csi" {
+	switch qtype[0] {
+	case "csi":
+	case "host":
+		return s.HostVolumesListRequest(resp, req)
+	default:
 		return nil, nil
 	}
 
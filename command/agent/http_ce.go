@@ -20,10 +20,12 @@ func (s *HTTPServer) registerEnterpriseHandlers() {
 	s.mux.HandleFunc("/v1/quota/", s.wrap(s.entOnly))
 	s.mux.HandleFunc("/v1/quota", s.wrap(s.entOnly))
 
+	// Recommendations generated from OOM history can be read in the community
+	// edition, but only enterprise can submit or apply them.
 	s.mux.HandleFunc("/v1/recommendation", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.entOnly))
+	s.mux.HandleFunc("/v1/recommendations", s.wrap(s.RecommendationsListRequest))
 	s.mux.HandleFunc("/v1/recommendations/apply", s.wrap(s.entOnly))
-	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.entOnly))
+	s.mux.HandleFunc("/v1/recommendation/", s.wrap(s.RecommendationSpecificRequest))
 }
 
 func (s *HTTPServer) entOnly(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
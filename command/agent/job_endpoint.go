@@ -118,6 +118,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/action"):
 		jobID := strings.TrimSuffix(path, "/action")
 		return s.jobRunAction(resp, req, jobID)
+	case strings.HasSuffix(path, "/prefetch"):
+		jobID := strings.TrimSuffix(path, "/prefetch")
+		return s.jobPrefetch(resp, req, jobID)
 	default:
 		return s.jobCRUD(resp, req, path)
 	}
@@ -742,6 +745,27 @@ func (s *HTTPServer) jobRevert(resp http.ResponseWriter, req *http.Request, jobI
 	return out, nil
 }
 
+func (s *HTTPServer) jobPrefetch(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
+
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobPrefetchRequest{
+		JobID:     jobID,
+		TaskGroup: req.URL.Query().Get("task_group"),
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.JobPrefetchResponse
+	if err := s.agent.RPC("Job.Prefetch", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) jobStable(resp http.ResponseWriter, req *http.Request, jobID string) (interface{}, error) {
 
 	if req.Method != "PUT" && req.Method != "POST" {
@@ -1255,6 +1279,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		if taskGroup.Update.Prefetch != nil {
+			tg.Update.Prefetch = *taskGroup.Update.Prefetch
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
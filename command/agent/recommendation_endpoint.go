@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// RecommendationsListRequest lists the recommendations generated from the OOM
+// history and peak memory usage of tasks.
+func (s *HTTPServer) RecommendationsListRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.RecommendationListRequest{
+		JobID: req.URL.Query().Get("job"),
+		Group: req.URL.Query().Get("group"),
+		Task:  req.URL.Query().Get("task"),
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.RecommendationListResponse
+	if err := s.agent.RPC("Recommendation.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendations == nil {
+		out.Recommendations = make([]*structs.Recommendation, 0)
+	}
+	return out.Recommendations, nil
+}
+
+// RecommendationSpecificRequest returns a single recommendation by ID.
+func (s *HTTPServer) RecommendationSpecificRequest(resp http.ResponseWriter, req *http.Request) (any, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	id := strings.TrimPrefix(req.URL.Path, "/v1/recommendation/")
+	if id == "" {
+		return nil, CodedError(http.StatusBadRequest, "missing recommendation ID")
+	}
+
+	args := structs.RecommendationSpecificRequest{
+		RecommendationID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.SingleRecommendationResponse
+	if err := s.agent.RPC("Recommendation.GetRecommendation", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Recommendation == nil {
+		return nil, CodedError(http.StatusNotFound, "recommendation not found")
+	}
+	return out.Recommendation, nil
+}
@@ -486,6 +486,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"job prefetch": func() (cli.Command, error) {
+			return &JobPrefetchCommand{
+				Meta: meta,
+			}, nil
+		},
 		"job promote": func() (cli.Command, error) {
 			return &JobPromoteCommand{
 				Meta: meta,
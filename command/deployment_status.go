@@ -601,7 +601,7 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, autorevert, progressDeadline, prefetch bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -614,6 +614,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
+		if state.Prefetch {
+			prefetch = true
+		}
 	}
 
 	// Sort the task group names to get a reliable ordering
@@ -631,6 +634,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	if canaries {
 		rowString += "Canaries|"
 	}
+	if prefetch {
+		rowString += "Prefetched|"
+	}
 	rowString += "Placed|Healthy|Unhealthy"
 	if progressDeadline {
 		rowString += "|Progress Deadline"
@@ -656,6 +662,13 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if canaries {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
 		}
+		if prefetch {
+			if state.Prefetch {
+				row += fmt.Sprintf("%d/%d|", state.PrefetchedNodes, state.PrefetchNodes)
+			} else {
+				row += fmt.Sprintf("%v|", "N/A")
+			}
+		}
 		row += fmt.Sprintf("%d|%d|%d", state.PlacedAllocs, state.HealthyAllocs, state.UnhealthyAllocs)
 		if progressDeadline {
 			if state.RequireProgressBy.IsZero() {
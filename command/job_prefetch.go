@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobPrefetchCommand struct {
+	Meta
+}
+
+func (c *JobPrefetchCommand) Help() string {
+	helpText := `
+Usage: nomad job prefetch [options] <job_id>
+
+  Prefetch the images and artifacts of a job's task groups on the nodes where
+  they could be placed, so that new allocations don't have to wait on them.
+  Candidate nodes are the ready nodes that pass the job's constraints and
+  have its task drivers. The command waits until every node is done.
+
+  Artifacts are prefetched for every task driver. Images are prefetched for
+  the task drivers that support it, such as the Docker driver.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  capability for the job's namespace. The 'list-jobs' capability is required to
+  run the command with a job prefix instead of the exact job ID.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Prefetch Options:
+
+  -group <name>
+    Only prefetch the given task group. Defaults to all task groups.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobPrefetchCommand) Synopsis() string {
+	return "Prefetch a job's images and artifacts on candidate nodes"
+}
+
+func (c *JobPrefetchCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-group":   complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *JobPrefetchCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobPrefetchCommand) Name() string { return "job prefetch" }
+
+func (c *JobPrefetchCommand) Run(args []string) int {
+	var group string
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&group, "group", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Check if the job exists
+	jobIDPrefix := strings.TrimSpace(args[0])
+	jobID, namespace, err := c.JobIDByPrefix(client, jobIDPrefix, nil)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	resp, _, err := client.Jobs().Prefetch(jobID, group, &api.WriteOptions{Namespace: namespace})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error prefetching job: %s", err))
+		return 1
+	}
+
+	if len(resp.Nodes) == 0 {
+		c.Ui.Output("No candidate nodes to prefetch on")
+		return 0
+	}
+
+	sort.Slice(resp.Nodes, func(i, j int) bool {
+		if resp.Nodes[i].TaskGroup != resp.Nodes[j].TaskGroup {
+			return resp.Nodes[i].TaskGroup < resp.Nodes[j].TaskGroup
+		}
+		return resp.Nodes[i].NodeID < resp.Nodes[j].NodeID
+	})
+
+	failed := 0
+	out := make([]string, len(resp.Nodes)+1)
+	out[0] = "Task Group|Node ID|Status"
+	for i, node := range resp.Nodes {
+		status := "prefetched"
+		if node.Error != "" {
+			status = fmt.Sprintf("failed: %s", node.Error)
+			failed++
+		}
+		out[i+1] = fmt.Sprintf("%s|%s|%s", node.TaskGroup, limit(node.NodeID, length), status)
+	}
+	c.Ui.Output(formatList(out))
+
+	if failed > 0 {
+		c.Ui.Error(fmt.Sprintf("\nPrefetch failed on %d of %d nodes", failed, len(resp.Nodes)))
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobPrefetchCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobPrefetchCommand{}
+}
+
+func TestJobPrefetchCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobPrefetchCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "foo"})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), "Error querying job prefix")
+}
+
+func TestJobPrefetchCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Create a job, there are no client nodes to prefetch on
+	job := mock.Job()
+	state := srv.Agent.Server().State()
+	must.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	ui := cli.NewMockUi()
+	cmd := &JobPrefetchCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-address=" + url, job.ID})
+	must.Zero(t, code)
+	must.StrContains(t, ui.OutputWriter.String(), "No candidate nodes")
+
+	code = cmd.Run([]string{"-address=" + url, "-group=unknown", job.ID})
+	must.One(t, code)
+	must.StrContains(t, ui.ErrorWriter.String(), `has no task group "unknown"`)
+}
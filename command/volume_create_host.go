@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/mitchellh/mapstructure"
+)
+
+func (c *VolumeCreateCommand) hostVolumeCreate(client *api.Client, ast *ast.File) int {
+	vol, err := decodeHostVolume(ast)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error decoding the volume definition: %s", err))
+		return 1
+	}
+
+	vol, _, err = client.HostVolumes().Create(vol, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"Created host volume %s with ID %s on node %s", vol.Name, vol.ID, vol.NodeID))
+	if vol.CapacityBytes > 0 {
+		c.Ui.Output(fmt.Sprintf("  Capacity: %s", humanize.IBytes(uint64(vol.CapacityBytes))))
+	}
+	return 0
+}
+
+func decodeHostVolume(input *ast.File) (*api.HostVolume, error) {
+	var err error
+	vol := &api.HostVolume{}
+
+	list, ok := input.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: root should be an object")
+	}
+
+	// Decode the full thing into a map[string]interface for ease
+	var m map[string]any
+	err = hcl.DecodeObject(&m, list)
+	if err != nil {
+		return nil, err
+	}
+
+	// Need to manually parse these fields
+	delete(m, "capacity_max")
+	delete(m, "capacity_min")
+	delete(m, "constraint")
+	delete(m, "type")
+
+	// Decode the rest
+	err = mapstructure.WeakDecode(m, vol)
+	if err != nil {
+		return nil, err
+	}
+
+	capacityMin, err := parseCapacityBytes(list.Filter("capacity_min"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_min: %v", err)
+	}
+	vol.RequestedCapacityMinBytes = capacityMin
+	capacityMax, err := parseCapacityBytes(list.Filter("capacity_max"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_max: %v", err)
+	}
+	vol.RequestedCapacityMaxBytes = capacityMax
+
+	constraints := list.Filter("constraint")
+	for _, o := range constraints.Elem().Items {
+		valid := []string{"attribute", "operator", "value"}
+		if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+			return nil, err
+		}
+
+		ot, ok := o.Val.(*ast.ObjectType)
+		if !ok {
+			break
+		}
+
+		var m map[string]any
+		if err := hcl.DecodeObject(&m, ot.List); err != nil {
+			return nil, err
+		}
+
+		constraint := &api.Constraint{Operand: "="}
+		for k, v := range m {
+			var s string
+			switch val := v.(type) {
+			case string:
+				s = val
+			case bool:
+				s = strconv.FormatBool(val)
+			default:
+				s = fmt.Sprint(val)
+			}
+			switch k {
+			case "attribute":
+				constraint.LTarget = s
+			case "operator":
+				constraint.Operand = s
+			case "value":
+				constraint.RTarget = s
+			}
+		}
+		vol.Constraints = append(vol.Constraints, constraint)
+	}
+
+	return vol, nil
+}
@@ -22,15 +22,15 @@ func (c *VolumeDeleteCommand) Help() string {
 	helpText := `
 Usage: nomad volume delete [options] <vol id>
 
-  Delete a volume from an external storage provider. The volume must still be
-  registered with Nomad in order to be deleted. Deleting will fail if the
-  volume is still in use by an allocation or in the process of being
-  unpublished. If the volume no longer exists, this command will silently
-  return without an error.
+  Delete a volume from an external storage provider, or a dynamic host volume
+  from its node. The volume must still be registered with Nomad in order to be
+  deleted. Deleting will fail if the volume is still in use by an allocation
+  or in the process of being unpublished. If the volume no longer exists, this
+  command will silently return without an error.
 
   When ACLs are enabled, this command requires a token with the
   'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
-  namespace.
+  namespace, or the 'host-volume-delete' capability for host volumes.
 
 General Options:
 
@@ -41,13 +41,19 @@ Delete Options:
   -secret
     Secrets to pass to the plugin to delete the snapshot. Accepts multiple
     flags in the form -secret key=value
+
+  -type <type>
+    Type of volume to delete. Valid values are "csi" and "host". Defaults to
+    "csi".
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *VolumeDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
-		complete.Flags{})
+		complete.Flags{
+			"-type": complete.PredictSet("csi", "host"),
+		})
 }
 
 func (c *VolumeDeleteCommand) AutocompleteArgs() complete.Predictor {
@@ -80,9 +86,11 @@ func (c *VolumeDeleteCommand) Name() string { return "volume delete" }
 
 func (c *VolumeDeleteCommand) Run(args []string) int {
 	var secretsArgs flaghelper.StringFlag
+	var typeArg string
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
 	flags.Var(&secretsArgs, "secret", "secrets for snapshot, ex. -secret key=value")
+	flags.StringVar(&typeArg, "type", "csi", "type of volume (csi or host)")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
@@ -105,6 +113,15 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 		return 1
 	}
 
+	switch typeArg {
+	case "csi":
+	case "host":
+		return c.deleteHostVolume(client, volID)
+	default:
+		c.Ui.Error(fmt.Sprintf("No such volume type %q", typeArg))
+		return 1
+	}
+
 	secrets := api.CSISecrets{}
 	for _, kv := range secretsArgs {
 		if key, value, found := strings.Cut(kv, "="); found {
@@ -127,3 +144,14 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
 	return 0
 }
+
+func (c *VolumeDeleteCommand) deleteHostVolume(client *api.Client, volID string) int {
+	_, err := client.HostVolumes().Delete(volID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error deleting volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf("Successfully deleted volume %q!", volID))
+	return 0
+}
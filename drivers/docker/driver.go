@@ -591,6 +591,37 @@ START:
 	return recoverableErrTimeouts(startErr)
 }
 
+// PrefetchTask pulls the task's image ahead of the task being placed on the
+// node. No task references the image yet, so it is garbage collected after
+// image_delay like any other unused image if no task starts using it.
+func (d *Driver) PrefetchTask(cfg *drivers.TaskConfig) error {
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+	if driverConfig.Image == "" {
+		return fmt.Errorf("image name required for docker driver")
+	}
+	driverConfig.Image = strings.TrimPrefix(driverConfig.Image, "https://")
+
+	// images loaded from an archive come from the task's artifacts
+	if driverConfig.LoadImage != "" {
+		return nil
+	}
+
+	dockerClient, err := d.getDockerClient()
+	if err != nil {
+		return fmt.Errorf("Failed to create docker client: %v", err)
+	}
+
+	id, err := d.createImage(cfg, &driverConfig, dockerClient)
+	if err != nil {
+		return err
+	}
+	d.coordinator.RemoveImage(id, cfg.ID)
+	return nil
+}
+
 // createImage creates a docker image either by pulling it from a registry or by
 // loading it from the file system
 func (d *Driver) createImage(task *drivers.TaskConfig, driverConfig *TaskConfig, client *docker.Client) (string, error) {
@@ -1664,6 +1695,8 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 		ExitResult:      h.ExitResult(),
 	}
 
+	setHealthAttributes(status.DriverAttributes, container.State.Health)
+
 	status.State = drivers.TaskStateUnknown
 	if container.State.Running {
 		status.State = drivers.TaskStateRunning
@@ -1675,6 +1708,28 @@ func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 	return status, nil
 }
 
+// setHealthAttributes sets the driver attributes reporting the state of the
+// HEALTHCHECK of the container as a Nomad check status. Containers without a
+// healthcheck, or whose healthcheck is disabled, have none.
+func setHealthAttributes(attrs map[string]string, health docker.Health) {
+	var status nstructs.CheckStatus
+	switch health.Status {
+	case "starting":
+		status = nstructs.CheckPending
+	case "healthy":
+		status = nstructs.CheckSuccess
+	case "unhealthy":
+		status = nstructs.CheckFailure
+	default:
+		return
+	}
+
+	attrs[drivers.TaskHealthStatusAttr] = string(status)
+	if n := len(health.Log); n > 0 {
+		attrs[drivers.TaskHealthOutputAttr] = strings.TrimSpace(health.Log[n-1].Output)
+	}
+}
+
 func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *drivers.TaskResourceUsage, error) {
 	h, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -3143,3 +3143,39 @@ func TestDockerDriver_GroupAdd(t *testing.T) {
 
 	require.Exactly(t, cfg.GroupAdd, container.HostConfig.GroupAdd)
 }
+
+func TestDockerDriver_setHealthAttributes(t *testing.T) {
+	ci.Parallel(t)
+
+	for _, tc := range []struct {
+		name   string
+		health docker.Health
+		exp    map[string]string
+	}{
+		{name: "no healthcheck", exp: map[string]string{}},
+		{name: "none", health: docker.Health{Status: "none"}, exp: map[string]string{}},
+		{name: "starting", health: docker.Health{Status: "starting"}, exp: map[string]string{
+			drivers.TaskHealthStatusAttr: "pending",
+		}},
+		{name: "healthy", health: docker.Health{
+			Status: "healthy",
+			Log:    []docker.HealthCheck{{Output: "failed\n"}, {Output: "ok\n"}},
+		}, exp: map[string]string{
+			drivers.TaskHealthStatusAttr: "success",
+			drivers.TaskHealthOutputAttr: "ok",
+		}},
+		{name: "unhealthy", health: docker.Health{
+			Status: "unhealthy",
+			Log:    []docker.HealthCheck{{ExitCode: 1, Output: "connection refused"}},
+		}, exp: map[string]string{
+			drivers.TaskHealthStatusAttr: "failure",
+			drivers.TaskHealthOutputAttr: "connection refused",
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			attrs := map[string]string{}
+			setHealthAttributes(attrs, tc.health)
+			must.Eq(t, tc.exp, attrs)
+		})
+	}
+}
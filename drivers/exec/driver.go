@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
 	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
 	"github.com/hashicorp/nomad/drivers/shared/resolvconf"
 	"github.com/hashicorp/nomad/helper/pluginutils/loader"
 	"github.com/hashicorp/nomad/helper/pointer"
@@ -83,17 +84,38 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"default_seccomp_profile": hclspec.NewDefault(
+			hclspec.NewAttr("default_seccomp_profile", "string", false),
+			hclspec.NewLiteral(`"unconfined"`),
+		),
+		"allow_seccomp_profiles": hclspec.NewDefault(
+			hclspec.NewAttr("allow_seccomp_profiles", "list(string)", false),
+			hclspec.NewLiteral(`["default", "unconfined"]`),
+		),
+		"default_userns_mode": hclspec.NewDefault(
+			hclspec.NewAttr("default_userns_mode", "string", false),
+			hclspec.NewLiteral(`"host"`),
+		),
+		"userns_id_start": hclspec.NewAttr("userns_id_start", "number", false),
+		"userns_id_count": hclspec.NewDefault(
+			hclspec.NewAttr("userns_id_count", "number", false),
+			hclspec.NewLiteral("65536"),
+		),
+		"image_cache_dir": hclspec.NewAttr("image_cache_dir", "string", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":      hclspec.NewAttr("command", "string", false),
+		"image":        hclspec.NewAttr("image", "string", false),
+		"args":         hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":     hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":     hclspec.NewAttr("ipc_mode", "string", false),
+		"userns_mode":  hclspec.NewAttr("userns_mode", "string", false),
+		"cap_add":      hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":     hclspec.NewAttr("cap_drop", "list(string)", false),
+		"security_opt": hclspec.NewAttr("security_opt", "list(string)", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -140,6 +162,10 @@ type Driver struct {
 
 	// compute contains cpu compute information
 	compute cpustats.Compute
+
+	// images is the cache of the images of tasks, created on first use
+	images     *ociimage.Cache
+	imagesLock sync.Mutex
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -159,6 +185,30 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// DefaultSeccompProfile is the seccomp profile applied to tasks that
+	// don't set one.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// AllowSeccompProfiles configures which seccomp profiles tasks running on
+	// this node can set.
+	AllowSeccompProfiles []string `codec:"allow_seccomp_profiles"`
+
+	// DefaultModeUserNS is the default user namespace isolation set for all
+	// tasks using exec-based task drivers.
+	DefaultModeUserNS string `codec:"default_userns_mode"`
+
+	// UserNSIDStart is the first host UID and GID mapped to the IDs of tasks
+	// running in a user namespace. User namespaces are disabled if unset.
+	UserNSIDStart uint32 `codec:"userns_id_start"`
+
+	// UserNSIDCount is the number of IDs mapped in the user namespace of
+	// tasks.
+	UserNSIDCount uint32 `codec:"userns_id_count"`
+
+	// ImageCacheDir is the directory of the unpacked layers of task images.
+	// Defaults to a directory next to the client's alloc dir.
+	ImageCacheDir string `codec:"image_cache_dir"`
 }
 
 func (c *Config) validate() error {
@@ -179,14 +229,27 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := executor.ValidateSeccompConfig(c.DefaultSeccompProfile, c.AllowSeccompProfiles); err != nil {
+		return err
+	}
+
+	if err := executor.ValidateUserNSConfig(c.DefaultModeUserNS, c.UserNSIDStart, c.UserNSIDCount); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // TaskConfig is the driver configuration of a task within a job
 type TaskConfig struct {
-	// Command is the thing to exec.
+	// Command is the thing to exec. Defaults to the entrypoint of the image
+	// if the task has one.
 	Command string `codec:"command"`
 
+	// Image is the path of an OCI image layout, or tarball of one, in the
+	// task directory. Its root filesystem replaces the chroot of the task.
+	Image string `codec:"image"`
+
 	// Args are passed along to Command.
 	Args []string `codec:"args"`
 
@@ -198,11 +261,18 @@ type TaskConfig struct {
 	// Must be "private" or "host" if set.
 	ModeIPC string `codec:"ipc_mode"`
 
+	// ModeUserNS indicates whether user namespace isolation is enabled for
+	// the task. Must be "private" or "host" if set.
+	ModeUserNS string `codec:"userns_mode"`
+
 	// CapAdd is a set of linux capabilities to enable.
 	CapAdd []string `codec:"cap_add"`
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SecurityOpt sets the seccomp profile and Landlock paths of the task.
+	SecurityOpt []string `codec:"security_opt"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -218,6 +288,12 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC)
 	}
 
+	switch tc.ModeUserNS {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		return fmt.Errorf("userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUserNS)
+	}
+
 	supported := capabilities.Supported()
 	badAdds := supported.Difference(capabilities.New(tc.CapAdd))
 	if !badAdds.Empty() {
@@ -228,6 +304,10 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if _, err := executor.ParseSecurityOpts(tc.SecurityOpt); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -364,6 +444,7 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported())
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -437,6 +518,41 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
 	}
 
+	securityOpts, err := executor.ParseSecurityOpts(driverConfig.SecurityOpt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	seccompProfile, err := executor.SeccompProfile(
+		d.config.DefaultSeccompProfile, d.config.AllowSeccompProfiles, securityOpts.Seccomp, cfg.TaskDir().Dir,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := executor.ValidateLandlock(securityOpts.Landlock); err != nil {
+		return nil, nil, err
+	}
+
+	var image *ociimage.Image
+	var imageRootfs string
+	if driverConfig.Image != "" {
+		image, imageRootfs, err = d.mountImage(cfg, driverConfig.Image)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to mount image: %v", err)
+		}
+	}
+
+	// remove the image root filesystem if the task fails to start
+	started := false
+	defer func() {
+		if !started && imageRootfs != "" {
+			_ = d.unmountImage(cfg)
+		}
+	}()
+	command, args, err := imageCommand(image, driverConfig.Command, driverConfig.Args)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	d.logger.Info("starting task", "driver_cfg", hclog.Fmt("%+v", driverConfig))
 	handle := drivers.NewTaskHandle(taskHandleVersion)
 	handle.Config = cfg
@@ -457,8 +573,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 
 	user := cfg.User
-	if user == "" {
+	switch {
+	case user != "":
+	case image == nil:
 		user = "nobody"
+	case image.Config.User != "":
+		user = image.Config.User
+	default:
+		user = imageDefaultUser
 	}
 
 	if cfg.DNS != nil {
@@ -478,9 +600,9 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
 	execCmd := &executor.ExecCommand{
-		Cmd:              driverConfig.Command,
-		Args:             driverConfig.Args,
-		Env:              cfg.EnvList(),
+		Cmd:              command,
+		Args:             args,
+		Env:              imageEnv(image, cfg),
 		User:             user,
 		ResourceLimits:   true,
 		NoPivotRoot:      d.config.NoPivotRoot,
@@ -493,7 +615,13 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
+		ModeUserNS:       executor.IsolationMode(d.config.DefaultModeUserNS, driverConfig.ModeUserNS),
+		UserNSIDStart:    d.config.UserNSIDStart,
+		UserNSIDCount:    d.config.UserNSIDCount,
+		ImageRootfs:      imageRootfs,
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		LandlockPaths:    securityOpts.Landlock,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -526,6 +654,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
+	started = true
 	d.tasks.Set(cfg.ID, h)
 	go h.run()
 	return handle, nil, nil
@@ -602,6 +731,10 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 		handle.pluginClient.Kill()
 	}
 
+	if err := d.unmountImage(handle.taskConfig); err != nil {
+		handle.logger.Error("failed to unmount image root filesystem", "error", err)
+	}
+
 	d.tasks.Delete(taskID)
 	return nil
 }
@@ -870,6 +870,48 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("seccomp", func(t *testing.T) {
+		for _, tc := range []struct {
+			profile string
+			allowed []string
+			exp     error
+		}{
+			{profile: "unconfined", allowed: []string{"default", "unconfined"}, exp: nil},
+			{profile: "unconfined", allowed: []string{"custom"}, exp: nil},
+			{profile: "other", allowed: nil, exp: errors.New(`default_seccomp_profile must be "default" or "unconfined", got "other"`)},
+			{profile: "unconfined", allowed: []string{"other"}, exp: errors.New(`allow_seccomp_profiles must only contain "default", "unconfined" or "custom", got "other"`)},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:        "private",
+				DefaultModeIPC:        "private",
+				DefaultSeccompProfile: tc.profile,
+				AllowSeccompProfiles:  tc.allowed,
+			}).validate())
+		}
+	})
+
+	t.Run("userns", func(t *testing.T) {
+		for _, tc := range []struct {
+			mode         string
+			start, count uint32
+			exp          error
+		}{
+			{mode: "host", start: 0, count: 65536, exp: nil},
+			{mode: "private", start: 100000, count: 65536, exp: nil},
+			{mode: "other", start: 0, count: 65536, exp: errors.New(`default_userns_mode must be "private" or "host", got "other"`)},
+			{mode: "private", start: 0, count: 65536, exp: errors.New(`default_userns_mode "private" requires userns_id_start to be set`)},
+			{mode: "host", start: 100000, count: 0, exp: errors.New("userns_id_count must be greater than 0")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:    "private",
+				DefaultModeIPC:    "private",
+				DefaultModeUserNS: tc.mode,
+				UserNSIDStart:     tc.start,
+				UserNSIDCount:     tc.count,
+			}).validate())
+		}
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -929,4 +971,36 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("userns_mode", func(t *testing.T) {
+		for _, tc := range []struct {
+			mode string
+			exp  error
+		}{
+			{mode: "", exp: nil},
+			{mode: "host", exp: nil},
+			{mode: "private", exp: nil},
+			{mode: "other", exp: errors.New(`userns_mode must be "private" or "host", got "other"`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				ModeUserNS: tc.mode,
+			}).validate())
+		}
+	})
+
+	t.Run("security_opt", func(t *testing.T) {
+		for _, tc := range []struct {
+			opts []string
+			exp  error
+		}{
+			{opts: nil, exp: nil},
+			{opts: []string{"seccomp=default", "landlock=d:r:/etc"}, exp: nil},
+			{opts: []string{"seccomp"}, exp: errors.New(`security_opt "seccomp" must be in the key=value format`)},
+			{opts: []string{"apparmor=unconfined"}, exp: errors.New(`security_opt "apparmor" is not supported`)},
+		} {
+			require.Equal(t, tc.exp, (&TaskConfig{
+				SecurityOpt: tc.opts,
+			}).validate())
+		}
+	})
 }
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// imageCacheDirName is the name of the default image cache directory,
+	// created next to the client's alloc dir
+	imageCacheDirName = "exec_images"
+
+	// imageDefaultUser is the user of image tasks that don't set one, and
+	// whose image doesn't either. It's the nobody user of most images.
+	imageDefaultUser = "65534:65534"
+)
+
+// imageCache returns the image cache of the driver, which defaults to a
+// directory next to the client's alloc dir.
+func (d *Driver) imageCache(allocDir string) *ociimage.Cache {
+	d.imagesLock.Lock()
+	defer d.imagesLock.Unlock()
+
+	if d.images == nil {
+		dir := d.config.ImageCacheDir
+		if dir == "" {
+			dir = filepath.Join(filepath.Dir(filepath.Dir(allocDir)), imageCacheDirName)
+		}
+		d.images = ociimage.NewCache(dir, d.logger)
+	}
+	return d.images
+}
+
+// imageRootfsName returns the name of the root filesystem of the task in the
+// image cache.
+func imageRootfsName(cfg *drivers.TaskConfig) string {
+	return strings.ReplaceAll(cfg.ID, "/", "_")
+}
+
+// mountImage loads the image of the task, whose path is relative to the task
+// directory, and mounts the root filesystem of the task.
+func (d *Driver) mountImage(cfg *drivers.TaskConfig, image string) (*ociimage.Image, string, error) {
+	taskDir := cfg.TaskDir().Dir
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", image)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve image %q: %v", image, err)
+	}
+	if escapes || filepath.IsAbs(image) {
+		return nil, "", fmt.Errorf("image %q must be a path in the task directory", image)
+	}
+
+	cache := d.imageCache(cfg.AllocDir)
+	img, err := cache.Load(filepath.Join(taskDir, image))
+	if err != nil {
+		return nil, "", err
+	}
+	rootfs, err := cache.Mount(img, imageRootfsName(cfg))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, rootfs, nil
+}
+
+// unmountImage removes the image root filesystem of the task, if any.
+func (d *Driver) unmountImage(cfg *drivers.TaskConfig) error {
+	return d.imageCache(cfg.AllocDir).Unmount(imageRootfsName(cfg))
+}
+
+// imageCommand returns the command and arguments of a task. Tasks that don't
+// set a command run the entrypoint of their image, with the arguments of the
+// task or the image's cmd.
+func imageCommand(img *ociimage.Image, command string, args []string) (string, []string, error) {
+	if command != "" {
+		return command, args, nil
+	}
+	if img == nil {
+		return "", nil, fmt.Errorf("command must be set for tasks without an image")
+	}
+
+	if len(args) == 0 {
+		args = img.Config.Cmd
+	}
+	argv := append(append([]string{}, img.Config.Entrypoint...), args...)
+	if len(argv) == 0 {
+		return "", nil, fmt.Errorf("command must be set, as the image has no entrypoint or cmd")
+	}
+	return argv[0], argv[1:], nil
+}
+
+// imageEnv returns the environment of the task, with the environment of its
+// image as defaults.
+func imageEnv(img *ociimage.Image, cfg *drivers.TaskConfig) []string {
+	env := cfg.EnvList()
+	if img == nil {
+		return env
+	}
+
+	defaults := make([]string, 0, len(img.Config.Env))
+	for _, kv := range img.Config.Env {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := cfg.Env[key]; !ok {
+			defaults = append(defaults, kv)
+		}
+	}
+	return append(defaults, env...)
+}
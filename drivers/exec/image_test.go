@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package exec
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+)
+
+func TestImage_imageCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	img := &ociimage.Image{Config: ocispec.ImageConfig{
+		Entrypoint: []string{"/bin/app", "--verbose"},
+		Cmd:        []string{"serve"},
+	}}
+
+	for _, tc := range []struct {
+		name    string
+		img     *ociimage.Image
+		command string
+		args    []string
+		expCmd  string
+		expArgs []string
+		expErr  string
+	}{
+		{name: "task command", img: img, command: "/bin/sh", args: []string{"-c", "true"}, expCmd: "/bin/sh", expArgs: []string{"-c", "true"}},
+		{name: "image defaults", img: img, expCmd: "/bin/app", expArgs: []string{"--verbose", "serve"}},
+		{name: "task args", img: img, args: []string{"check"}, expCmd: "/bin/app", expArgs: []string{"--verbose", "check"}},
+		{name: "cmd only", img: &ociimage.Image{Config: ocispec.ImageConfig{Cmd: []string{"/bin/sh"}}}, expCmd: "/bin/sh", expArgs: []string{}},
+		{name: "no image", expErr: "command must be set for tasks without an image"},
+		{name: "empty image", img: &ociimage.Image{}, expErr: "the image has no entrypoint or cmd"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, args, err := imageCommand(tc.img, tc.command, tc.args)
+			if tc.expErr != "" {
+				must.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			must.NoError(t, err)
+			must.Eq(t, tc.expCmd, cmd)
+			must.Eq(t, tc.expArgs, args)
+		})
+	}
+}
+
+func TestImage_imageEnv(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{Env: map[string]string{
+		"PATH":     "/task/bin",
+		"NOMAD_ID": "1",
+	}}
+	img := &ociimage.Image{Config: ocispec.ImageConfig{
+		Env: []string{"PATH=/usr/bin", "LANG=C.UTF-8"},
+	}}
+
+	must.Eq(t, []string{"NOMAD_ID=1", "PATH=/task/bin"}, imageEnv(nil, cfg))
+	must.Eq(t, []string{"LANG=C.UTF-8", "NOMAD_ID=1", "PATH=/task/bin"}, imageEnv(img, cfg))
+}
@@ -6,6 +6,7 @@ package java
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
+	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/client/lib/cgroupslib"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/eventer"
@@ -81,6 +83,24 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"default_seccomp_profile": hclspec.NewDefault(
+			hclspec.NewAttr("default_seccomp_profile", "string", false),
+			hclspec.NewLiteral(`"unconfined"`),
+		),
+		"allow_seccomp_profiles": hclspec.NewDefault(
+			hclspec.NewAttr("allow_seccomp_profiles", "list(string)", false),
+			hclspec.NewLiteral(`["default", "unconfined"]`),
+		),
+		"default_userns_mode": hclspec.NewDefault(
+			hclspec.NewAttr("default_userns_mode", "string", false),
+			hclspec.NewLiteral(`"host"`),
+		),
+		"userns_id_start": hclspec.NewAttr("userns_id_start", "number", false),
+		"userns_id_count": hclspec.NewDefault(
+			hclspec.NewAttr("userns_id_count", "number", false),
+			hclspec.NewLiteral("65536"),
+		),
+		"jdk_homes": hclspec.NewAttr("jdk_homes", "list(string)", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
@@ -89,15 +109,18 @@ var (
 		// It's required for either `class` or `jar_path` to be set,
 		// but that's not expressable in hclspec.  Marking both as optional
 		// and setting checking explicitly later
-		"class":       hclspec.NewAttr("class", "string", false),
-		"class_path":  hclspec.NewAttr("class_path", "string", false),
-		"jar_path":    hclspec.NewAttr("jar_path", "string", false),
-		"jvm_options": hclspec.NewAttr("jvm_options", "list(string)", false),
-		"args":        hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
+		"class":        hclspec.NewAttr("class", "string", false),
+		"class_path":   hclspec.NewAttr("class_path", "string", false),
+		"jar_path":     hclspec.NewAttr("jar_path", "string", false),
+		"jvm_options":  hclspec.NewAttr("jvm_options", "list(string)", false),
+		"args":         hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":     hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":     hclspec.NewAttr("ipc_mode", "string", false),
+		"userns_mode":  hclspec.NewAttr("userns_mode", "string", false),
+		"cap_add":      hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":     hclspec.NewAttr("cap_drop", "list(string)", false),
+		"security_opt": hclspec.NewAttr("security_opt", "list(string)", false),
+		"jdk_version":  hclspec.NewAttr("jdk_version", "string", false),
 	})
 
 	// driverCapabilities is returned by the Capabilities RPC and indicates what
@@ -136,6 +159,30 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// DefaultSeccompProfile is the seccomp profile applied to tasks that
+	// don't set one.
+	DefaultSeccompProfile string `codec:"default_seccomp_profile"`
+
+	// AllowSeccompProfiles configures which seccomp profiles tasks running on
+	// this node can set.
+	AllowSeccompProfiles []string `codec:"allow_seccomp_profiles"`
+
+	// DefaultModeUserNS is the default user namespace isolation set for all
+	// tasks using exec-based task drivers.
+	DefaultModeUserNS string `codec:"default_userns_mode"`
+
+	// UserNSIDStart is the first host UID and GID mapped to the IDs of tasks
+	// running in a user namespace. User namespaces are disabled if unset.
+	UserNSIDStart uint32 `codec:"userns_id_start"`
+
+	// UserNSIDCount is the number of IDs mapped in the user namespace of
+	// tasks.
+	UserNSIDCount uint32 `codec:"userns_id_count"`
+
+	// JDKHomes are the JDKs installed on this node that tasks can select
+	// with jdk_version.
+	JDKHomes []string `codec:"jdk_homes"`
 }
 
 func (c *Config) validate() error {
@@ -156,6 +203,20 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	if err := executor.ValidateSeccompConfig(c.DefaultSeccompProfile, c.AllowSeccompProfiles); err != nil {
+		return err
+	}
+
+	if err := executor.ValidateUserNSConfig(c.DefaultModeUserNS, c.UserNSIDStart, c.UserNSIDCount); err != nil {
+		return err
+	}
+
+	for _, home := range c.JDKHomes {
+		if !filepath.IsAbs(home) {
+			return fmt.Errorf("jdk_homes must be absolute paths, got %q", home)
+		}
+	}
+
 	return nil
 }
 
@@ -184,11 +245,22 @@ type TaskConfig struct {
 	// Must be "private" or "host" if set.
 	ModeIPC string `codec:"ipc_mode"`
 
+	// ModeUserNS indicates whether user namespace isolation is enabled for
+	// the task. Must be "private" or "host" if set.
+	ModeUserNS string `codec:"userns_mode"`
+
 	// CapAdd is a set of linux capabilities to enable.
 	CapAdd []string `codec:"cap_add"`
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// SecurityOpt sets the seccomp profile and Landlock paths of the task.
+	SecurityOpt []string `codec:"security_opt"`
+
+	// JDKVersion is a version constraint selecting the JDK running the task
+	// among the jdk_homes of the node.
+	JDKVersion string `codec:"jdk_version"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -205,6 +277,12 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC)
 	}
 
+	switch tc.ModeUserNS {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		return fmt.Errorf("userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUserNS)
+	}
+
 	supported := capabilities.Supported()
 	badAdds := supported.Difference(capabilities.New(tc.CapAdd))
 	if !badAdds.Empty() {
@@ -215,6 +293,16 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("cap_drop configured with capabilities not supported by system: %s", badDrops)
 	}
 
+	if _, err := executor.ParseSecurityOpts(tc.SecurityOpt); err != nil {
+		return err
+	}
+
+	if tc.JDKVersion != "" {
+		if _, err := version.NewConstraint(tc.JDKVersion); err != nil {
+			return fmt.Errorf("invalid jdk_version %q: %v", tc.JDKVersion, err)
+		}
+	}
+
 	return nil
 }
 
@@ -351,7 +439,14 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		}
 	}
 
+	jdks := findJDKs(d.logger, d.config.JDKHomes)
+
 	version, jdkJRE, vm, err := javaVersionInfo()
+	if err != nil && len(jdks) > 0 {
+		// report the latest JDK when java isn't on the PATH
+		latest := jdks[len(jdks)-1]
+		version, jdkJRE, vm, err = jdkVersionInfo(latest.home)
+	}
 	if err != nil {
 		// return no error, as it isn't an error to not find java, it just means we
 		// can't use it.
@@ -360,10 +455,12 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 		return fp
 	}
 
+	maps.Copy(fp.Attributes, jdkAttributes(jdks))
 	fp.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
 	fp.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(version)
 	fp.Attributes["driver.java.runtime"] = pstructs.NewStringAttribute(jdkJRE)
 	fp.Attributes["driver.java.vm"] = pstructs.NewStringAttribute(vm)
+	fp.Attributes["driver.java.seccomp"] = pstructs.NewBoolAttribute(executor.SeccompSupported())
 
 	return fp
 }
@@ -440,9 +537,37 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("jar_path or class must be specified")
 	}
 
-	absPath, err := GetAbsolutePath("java")
+	securityOpts, err := executor.ParseSecurityOpts(driverConfig.SecurityOpt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed driver config validation: %v", err)
+	}
+	seccompProfile, err := executor.SeccompProfile(
+		d.config.DefaultSeccompProfile, d.config.AllowSeccompProfiles, securityOpts.Seccomp, cfg.TaskDir().Dir,
+	)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to find java binary: %s", err)
+		return nil, nil, err
+	}
+	if err := executor.ValidateLandlock(securityOpts.Landlock); err != nil {
+		return nil, nil, err
+	}
+
+	var absPath string
+	if driverConfig.JDKVersion != "" {
+		jdk, err := selectJDK(findJDKs(d.logger, d.config.JDKHomes), driverConfig.JDKVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		absPath = jdk.javaPath()
+		if cfg.Env == nil {
+			cfg.Env = map[string]string{}
+		}
+		cfg.Env["JAVA_HOME"] = jdk.home
+		d.logger.Debug("selected JDK", "jdk_home", jdk.home, "version", jdk.version)
+	} else {
+		absPath, err = GetAbsolutePath("java")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find java binary: %s", err)
+		}
 	}
 
 	args := javaCmdArgs(driverConfig)
@@ -503,7 +628,12 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		NetworkIsolation: cfg.NetworkIsolation,
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
+		ModeUserNS:       executor.IsolationMode(d.config.DefaultModeUserNS, driverConfig.ModeUserNS),
+		UserNSIDStart:    d.config.UserNSIDStart,
+		UserNSIDCount:    d.config.UserNSIDCount,
 		Capabilities:     caps,
+		SeccompProfile:   seccompProfile,
+		LandlockPaths:    securityOpts.Landlock,
 	}
 
 	ps, err := exec.Launch(execCmd)
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package java
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	hclog "github.com/hashicorp/go-hclog"
+	version "github.com/hashicorp/go-version"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+// driverJDKsAttr is the node attribute listing the versions of the JDKs
+// configured with jdk_homes, which tasks setting jdk_version are constrained
+// on.
+const driverJDKsAttr = "driver.java.jdks"
+
+// jdk is a JDK configured with jdk_homes
+type jdk struct {
+	home    string
+	version *version.Version
+	runtime string
+	vm      string
+}
+
+// major returns the major version of the JDK
+func (j *jdk) major() int {
+	return j.version.Segments()[0]
+}
+
+// javaPath returns the path of the java binary of the JDK
+func (j *jdk) javaPath() string {
+	return filepath.Join(j.home, "bin", "java")
+}
+
+// jdkVersion parses the version reported by a java binary. Versions prior to
+// Java 9 have the "1.8.0_392" format, which is normalized to "8.0.392" so all
+// versions compare by their major version.
+func jdkVersion(v string) (*version.Version, error) {
+	if rest, ok := strings.CutPrefix(v, "1."); ok {
+		v = rest
+	}
+	return version.NewVersion(strings.ReplaceAll(v, "_", "."))
+}
+
+// findJDKs returns the JDKs installed at the homes, ordered by version. The
+// homes whose java binary can't be run are skipped.
+func findJDKs(logger hclog.Logger, homes []string) []*jdk {
+	var jdks []*jdk
+	for _, home := range homes {
+		v, runtime, vm, err := jdkVersionInfo(home)
+		if err != nil {
+			logger.Warn("failed to fingerprint JDK", "jdk_home", home, "error", err)
+			continue
+		}
+		parsed, err := jdkVersion(v)
+		if err != nil {
+			logger.Warn("failed to parse JDK version", "jdk_home", home, "version", v, "error", err)
+			continue
+		}
+		jdks = append(jdks, &jdk{home: home, version: parsed, runtime: runtime, vm: vm})
+	}
+
+	sort.SliceStable(jdks, func(i, j int) bool {
+		return jdks[i].version.LessThan(jdks[j].version)
+	})
+	return jdks
+}
+
+// jdkAttributes returns the node attributes of the JDKs. Each JDK is
+// fingerprinted by its major version, and only the latest JDK of each major
+// version is reported.
+func jdkAttributes(jdks []*jdk) map[string]*pstructs.Attribute {
+	attrs := map[string]*pstructs.Attribute{}
+	if len(jdks) == 0 {
+		return attrs
+	}
+
+	versions := make([]string, 0, len(jdks))
+	for _, j := range jdks {
+		versions = append(versions, j.version.String())
+
+		prefix := fmt.Sprintf("driver.java.jdk.%d.", j.major())
+		attrs[prefix+"version"] = pstructs.NewStringAttribute(j.version.String())
+		attrs[prefix+"home"] = pstructs.NewStringAttribute(j.home)
+		attrs[prefix+"runtime"] = pstructs.NewStringAttribute(j.runtime)
+		attrs[prefix+"vm"] = pstructs.NewStringAttribute(j.vm)
+	}
+	attrs[driverJDKsAttr] = pstructs.NewStringAttribute(strings.Join(versions, ","))
+	return attrs
+}
+
+// selectJDK returns the latest of the JDKs matching the version constraint.
+func selectJDK(jdks []*jdk, constraint string) (*jdk, error) {
+	c, err := version.NewConstraint(constraint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jdk_version %q: %v", constraint, err)
+	}
+	for i := len(jdks) - 1; i >= 0; i-- {
+		if c.Check(jdks[i].version) {
+			return jdks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no JDK configured with jdk_homes matches jdk_version %q", constraint)
+}
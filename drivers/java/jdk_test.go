@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package java
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// testJDKHome returns the home of a fake JDK whose java binary reports the
+// given version.
+func testJDKHome(t *testing.T, version string) string {
+	t.Helper()
+	home := t.TempDir()
+	must.NoError(t, os.MkdirAll(filepath.Join(home, "bin"), 0o755))
+	script := fmt.Sprintf(`#!/bin/sh
+cat >&2 <<EOF
+openjdk version "%s" 2023-10-17
+OpenJDK Runtime Environment (build %s)
+OpenJDK 64-Bit Server VM (build %s, mixed mode)
+EOF
+`, version, version, version)
+	must.NoError(t, os.WriteFile(filepath.Join(home, "bin", "java"), []byte(script), 0o755))
+	return home
+}
+
+func TestJDK_jdkVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	for input, expected := range map[string]string{
+		"1.8.0_392": "8.0.392",
+		"11.0.21":   "11.0.21",
+		"21":        "21.0.0",
+	} {
+		v, err := jdkVersion(input)
+		must.NoError(t, err)
+		must.Eq(t, expected, v.String())
+	}
+
+	_, err := jdkVersion("")
+	must.Error(t, err)
+}
+
+func TestJDK_findJDKs(t *testing.T) {
+	ci.Parallel(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh to run")
+	}
+
+	jdk21 := testJDKHome(t, "21.0.1")
+	jdk8 := testJDKHome(t, "1.8.0_392")
+	jdk17 := testJDKHome(t, "17.0.9")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	jdks := findJDKs(hclog.NewNullLogger(), []string{jdk21, missing, jdk8, jdk17})
+	must.Len(t, 3, jdks)
+	must.Eq(t, jdk8, jdks[0].home)
+	must.Eq(t, jdk17, jdks[1].home)
+	must.Eq(t, jdk21, jdks[2].home)
+	must.Eq(t, filepath.Join(jdk17, "bin", "java"), jdks[1].javaPath())
+
+	attrs := jdkAttributes(jdks)
+	must.Eq(t, "8.0.392,17.0.9,21.0.1", attrs[driverJDKsAttr].GoString())
+	must.Eq(t, "17.0.9", attrs["driver.java.jdk.17.version"].GoString())
+	must.Eq(t, jdk17, attrs["driver.java.jdk.17.home"].GoString())
+	must.Eq(t, "OpenJDK Runtime Environment (build 1.8.0_392)", attrs["driver.java.jdk.8.runtime"].GoString())
+	must.MapEmpty(t, jdkAttributes(nil))
+
+	jdk, err := selectJDK(jdks, ">= 17")
+	must.NoError(t, err)
+	must.Eq(t, jdk21, jdk.home)
+
+	jdk, err = selectJDK(jdks, "~> 17.0")
+	must.NoError(t, err)
+	must.Eq(t, jdk17, jdk.home)
+
+	_, err = selectJDK(jdks, "< 8")
+	must.ErrorContains(t, err, "no JDK configured with jdk_homes matches")
+
+	_, err = selectJDK(jdks, "seventeen")
+	must.ErrorContains(t, err, "invalid jdk_version")
+}
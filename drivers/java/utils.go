@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	rt "runtime"
 	"strings"
@@ -30,8 +31,6 @@ func checkForMacJVM() (ok bool, err error) {
 }
 
 func javaVersionInfo() (version, runtime, vm string, err error) {
-	var out bytes.Buffer
-
 	if rt.GOOS == "darwin" {
 		_, err = checkForMacJVM()
 		if err != nil {
@@ -40,7 +39,18 @@ func javaVersionInfo() (version, runtime, vm string, err error) {
 		}
 	}
 
-	cmd := exec.Command(javaVersionCommand[0], javaVersionCommand[1:]...)
+	return runJavaVersion(javaVersionCommand)
+}
+
+// jdkVersionInfo returns the version information of the java binary of the
+// JDK installed at home.
+func jdkVersionInfo(home string) (version, runtime, vm string, err error) {
+	return runJavaVersion([]string{filepath.Join(home, "bin", "java"), "-version"})
+}
+
+func runJavaVersion(command []string) (version, runtime, vm string, err error) {
+	var out bytes.Buffer
+	cmd := exec.Command(command[0], command[1:]...)
 	cmd.Stdout = &out
 	cmd.Stderr = &out
 	err = cmd.Run()
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/shoenig/test/must"
+)
+
+func TestConfig_ParseAllHCL(t *testing.T) {
+	ci.Parallel(t)
+
+	cfgStr := `
+config {
+  bundle   = "local/bundle"
+  command  = "/bin/app"
+  args     = ["serve"]
+  cap_add  = ["net_raw"]
+  cap_drop = ["chown"]
+}`
+
+	expected := &TaskConfig{
+		Bundle:  "local/bundle",
+		Command: "/bin/app",
+		Args:    []string{"serve"},
+		CapAdd:  []string{"net_raw"},
+		CapDrop: []string{"chown"},
+	}
+
+	var tc *TaskConfig
+	hclutils.NewConfigParser(taskConfigSpec).ParseHCL(t, cfgStr, &tc)
+	must.Eq(t, expected, tc)
+}
+
+func TestConfig_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, (&Config{AllowCaps: []string{"chown", "net_raw"}}).validate())
+	must.ErrorContains(t, (&Config{AllowCaps: []string{"chown", "not_valid"}}).validate(),
+		"allow_caps configured with capabilities not supported by system: not_valid")
+}
+
+func TestTaskConfig_validate(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("source", func(t *testing.T) {
+		must.NoError(t, (&TaskConfig{Bundle: "local/bundle"}).validate())
+		must.NoError(t, (&TaskConfig{Image: "local/image.tar"}).validate())
+		must.ErrorContains(t, (&TaskConfig{}).validate(),
+			"exactly one of bundle or image must be set")
+		must.ErrorContains(t, (&TaskConfig{Bundle: "local/bundle", Image: "local/image.tar"}).validate(),
+			"exactly one of bundle or image must be set")
+	})
+
+	t.Run("capabilities", func(t *testing.T) {
+		must.ErrorContains(t, (&TaskConfig{Image: "img", CapAdd: []string{"not_valid"}}).validate(),
+			"cap_add configured with capabilities not supported by system: not_valid")
+		must.ErrorContains(t, (&TaskConfig{Image: "img", CapDrop: []string{"not_valid"}}).validate(),
+			"cap_drop configured with capabilities not supported by system: not_valid")
+	})
+}
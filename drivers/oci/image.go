@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+// imageCacheDirName is the name of the default image cache directory, created
+// next to the client's alloc dir
+const imageCacheDirName = "oci_images"
+
+// imageCache returns the image cache of the driver, which defaults to a
+// directory next to the client's alloc dir.
+func (d *Driver) imageCache(allocDir string) *ociimage.Cache {
+	d.imagesLock.Lock()
+	defer d.imagesLock.Unlock()
+
+	if d.images == nil {
+		dir := d.config.ImageCacheDir
+		if dir == "" {
+			dir = filepath.Join(filepath.Dir(filepath.Dir(allocDir)), imageCacheDirName)
+		}
+		d.images = ociimage.NewCache(dir, d.logger)
+	}
+	return d.images
+}
+
+// imageRootfsName returns the name of the root filesystem of the task in the
+// image cache.
+func imageRootfsName(cfg *drivers.TaskConfig) string {
+	return strings.ReplaceAll(cfg.ID, "/", "_")
+}
+
+// mountImage loads the image of the task, whose path is relative to the task
+// directory, and mounts the root filesystem of the task.
+func (d *Driver) mountImage(cfg *drivers.TaskConfig, image string) (*ociimage.Image, string, error) {
+	path, err := taskPath(cfg.TaskDir().Dir, "", image)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve image %q: %v", image, err)
+	}
+
+	cache := d.imageCache(cfg.AllocDir)
+	img, err := cache.Load(path)
+	if err != nil {
+		return nil, "", err
+	}
+	rootfs, err := cache.Mount(img, imageRootfsName(cfg))
+	if err != nil {
+		return nil, "", err
+	}
+	return img, rootfs, nil
+}
+
+// unmountImage removes the image root filesystem of the task, if any.
+func (d *Driver) unmountImage(cfg *drivers.TaskConfig) error {
+	return d.imageCache(cfg.AllocDir).Unmount(imageRootfsName(cfg))
+}
@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// bundleConfigName is the name of the runtime spec of a bundle
+	bundleConfigName = "config.json"
+
+	// defaultUser is the user of tasks that don't set one, and whose image
+	// doesn't either. It's the nobody user of most images.
+	defaultUser = "65534:65534"
+)
+
+// nomadMountDestinations are the destinations of the filesystems Nomad mounts
+// in every container, whose mounts are skipped in runtime specs
+var nomadMountDestinations = []string{
+	"/proc", "/dev", "/dev/pts", "/dev/shm", "/dev/mqueue", "/sys", "/sys/fs/cgroup",
+}
+
+// container is the process of a task and the root filesystem it runs in, as
+// described by a runtime spec or an image
+type container struct {
+	// args is the command and arguments of the process
+	args []string
+
+	// env is the environment of the process, which the environment of the
+	// task overrides
+	env []string
+
+	// cwd is the working directory of the process
+	cwd string
+
+	// user is the user of the process, which the user of the task overrides
+	user string
+
+	// capabilities are the bounding capabilities of the process, or nil to
+	// use the defaults of Nomad
+	capabilities []string
+
+	// rootfs is the host path of the root filesystem
+	rootfs string
+
+	// mounts are the bind mounts of the runtime spec
+	mounts []*drivers.MountConfig
+}
+
+// bundleContainer returns the container of the OCI bundle, whose path is
+// relative to the task directory. The root filesystem and bind mounts of the
+// bundle must be in the task directory.
+func bundleContainer(taskDir, bundle string) (*container, error) {
+	bundleDir, err := taskPath(taskDir, "", bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(bundleDir, bundleConfigName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runtime spec: %v", err)
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode runtime spec: %v", err)
+	}
+	return specContainer(taskDir, bundleDir, &spec)
+}
+
+// specContainer returns the container of the runtime spec of the bundle.
+func specContainer(taskDir, bundleDir string, spec *specs.Spec) (*container, error) {
+	if spec.Process == nil {
+		return nil, fmt.Errorf("runtime spec must have a process")
+	}
+	if spec.Root == nil || spec.Root.Path == "" {
+		return nil, fmt.Errorf("runtime spec must have a root path")
+	}
+	if spec.Hooks != nil {
+		return nil, fmt.Errorf("runtime spec hooks are not supported")
+	}
+
+	rootfs, err := taskPath(taskDir, bundleDir, spec.Root.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid root path: %v", err)
+	}
+
+	c := &container{
+		args:   spec.Process.Args,
+		env:    spec.Process.Env,
+		cwd:    spec.Process.Cwd,
+		user:   fmt.Sprintf("%d:%d", spec.Process.User.UID, spec.Process.User.GID),
+		rootfs: rootfs,
+	}
+	if caps := spec.Process.Capabilities; caps != nil {
+		c.capabilities = caps.Bounding
+	}
+
+	for _, m := range spec.Mounts {
+		bind := m.Type == "bind" || slices.Contains(m.Options, "bind") || slices.Contains(m.Options, "rbind")
+		if !bind {
+			if slices.Contains(nomadMountDestinations, filepath.Clean(m.Destination)) {
+				continue
+			}
+			return nil, fmt.Errorf("mount of %q has unsupported type %q", m.Destination, m.Type)
+		}
+
+		source, err := taskPath(taskDir, bundleDir, m.Source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source of mount %q: %v", m.Destination, err)
+		}
+		c.mounts = append(c.mounts, &drivers.MountConfig{
+			TaskPath: m.Destination,
+			HostPath: source,
+			Readonly: slices.Contains(m.Options, "ro"),
+		})
+	}
+	return c, nil
+}
+
+// imageContainer returns the container of the image mounted at rootfs.
+func imageContainer(img *ociimage.Image, rootfs string) *container {
+	return &container{
+		args:   append(slices.Clone(img.Config.Entrypoint), img.Config.Cmd...),
+		env:    img.Config.Env,
+		cwd:    img.Config.WorkingDir,
+		user:   img.Config.User,
+		rootfs: rootfs,
+	}
+}
+
+// command returns the command and arguments of the container. The command
+// of the task replaces the arguments of the container, and the arguments of
+// the task replace all but the first one.
+func (c *container) command(command string, args []string) (string, []string, error) {
+	argv := c.args
+	switch {
+	case command != "":
+		argv = append([]string{command}, args...)
+	case len(args) > 0 && len(argv) > 0:
+		argv = append([]string{argv[0]}, args...)
+	}
+	if len(argv) == 0 {
+		return "", nil, fmt.Errorf("command must be set, as the container has no process arguments")
+	}
+	return argv[0], argv[1:], nil
+}
+
+// environment returns the environment of the task, with the environment of
+// the container as defaults.
+func (c *container) environment(cfg *drivers.TaskConfig) []string {
+	env := cfg.EnvList()
+	defaults := make([]string, 0, len(c.env))
+	for _, kv := range c.env {
+		key, _, _ := strings.Cut(kv, "=")
+		if _, ok := cfg.Env[key]; !ok {
+			defaults = append(defaults, kv)
+		}
+	}
+	return append(defaults, env...)
+}
+
+// taskPath returns the host path of a path relative to dir, or to the task
+// directory if dir is empty, which must not escape the task directory.
+// Absolute paths are host paths.
+func taskPath(taskDir, dir, path string) (string, error) {
+	if dir == "" {
+		dir = taskDir
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	rel, err := filepath.Rel(taskDir, path)
+	if err != nil {
+		return "", err
+	}
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", rel)
+	if err != nil {
+		return "", err
+	}
+	if escapes {
+		return "", fmt.Errorf("%q must be a path in the task directory", path)
+	}
+	return path, nil
+}
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package oci
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/drivers/shared/ociimage"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/shoenig/test/must"
+)
+
+// writeBundle writes the runtime spec of a bundle in the task directory.
+func writeBundle(t *testing.T, taskDir, bundle string, spec *specs.Spec) {
+	t.Helper()
+	dir := filepath.Join(taskDir, bundle)
+	must.NoError(t, os.MkdirAll(filepath.Join(dir, "rootfs"), 0o755))
+	data, err := json.Marshal(spec)
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(dir, bundleConfigName), data, 0o644))
+}
+
+func TestSpec_bundleContainer(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	writeBundle(t, taskDir, "local/bundle", &specs.Spec{
+		Version: specs.Version,
+		Process: &specs.Process{
+			Args: []string{"/bin/app", "serve"},
+			Env:  []string{"PATH=/bin"},
+			Cwd:  "/srv",
+			User: specs.User{UID: 1000, GID: 100},
+			Capabilities: &specs.LinuxCapabilities{
+				Bounding: []string{"CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+			},
+		},
+		Root: &specs.Root{Path: "rootfs"},
+		Mounts: []specs.Mount{
+			{Destination: "/proc", Type: "proc", Source: "proc"},
+			{Destination: "/dev/pts", Type: "devpts", Source: "devpts"},
+			{Destination: "/data", Type: "bind", Source: "data", Options: []string{"rbind", "ro"}},
+			{Destination: "/shared", Source: "../../alloc/data", Options: []string{"bind"}},
+		},
+	})
+
+	c, err := bundleContainer(taskDir, "local/bundle")
+	must.NoError(t, err)
+	must.Eq(t, &container{
+		args:         []string{"/bin/app", "serve"},
+		env:          []string{"PATH=/bin"},
+		cwd:          "/srv",
+		user:         "1000:100",
+		capabilities: []string{"CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+		rootfs:       filepath.Join(taskDir, "local/bundle/rootfs"),
+		mounts: []*drivers.MountConfig{
+			{TaskPath: "/data", HostPath: filepath.Join(taskDir, "local/bundle/data"), Readonly: true},
+			{TaskPath: "/shared", HostPath: filepath.Join(taskDir, "alloc/data")},
+		},
+	}, c)
+
+	_, err = bundleContainer(taskDir, "local/missing")
+	must.ErrorContains(t, err, "failed to read runtime spec")
+}
+
+func TestSpec_specContainer_invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	bundleDir := filepath.Join(taskDir, "local")
+	valid := func() *specs.Spec {
+		return &specs.Spec{
+			Process: &specs.Process{Args: []string{"/bin/app"}},
+			Root:    &specs.Root{Path: "rootfs"},
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*specs.Spec)
+		err    string
+	}{
+		{
+			name:   "no process",
+			modify: func(s *specs.Spec) { s.Process = nil },
+			err:    "runtime spec must have a process",
+		},
+		{
+			name:   "no root",
+			modify: func(s *specs.Spec) { s.Root = nil },
+			err:    "runtime spec must have a root path",
+		},
+		{
+			name:   "hooks",
+			modify: func(s *specs.Spec) { s.Hooks = &specs.Hooks{} },
+			err:    "runtime spec hooks are not supported",
+		},
+		{
+			name:   "root outside of task dir",
+			modify: func(s *specs.Spec) { s.Root.Path = "/" },
+			err:    "invalid root path",
+		},
+		{
+			name: "mount outside of task dir",
+			modify: func(s *specs.Spec) {
+				s.Mounts = []specs.Mount{{Destination: "/etc", Type: "bind", Source: "../../../etc"}}
+			},
+			err: `invalid source of mount "/etc"`,
+		},
+		{
+			name: "unsupported mount",
+			modify: func(s *specs.Spec) {
+				s.Mounts = []specs.Mount{{Destination: "/run", Type: "tmpfs", Source: "tmpfs"}}
+			},
+			err: `mount of "/run" has unsupported type "tmpfs"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := valid()
+			tc.modify(spec)
+			_, err := specContainer(taskDir, bundleDir, spec)
+			must.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestSpec_imageContainer(t *testing.T) {
+	ci.Parallel(t)
+
+	img := &ociimage.Image{Config: ocispec.ImageConfig{
+		Entrypoint: []string{"/bin/app"},
+		Cmd:        []string{"serve"},
+		Env:        []string{"PATH=/bin"},
+		WorkingDir: "/srv",
+		User:       "app",
+	}}
+	must.Eq(t, &container{
+		args:   []string{"/bin/app", "serve"},
+		env:    []string{"PATH=/bin"},
+		cwd:    "/srv",
+		user:   "app",
+		rootfs: "/rootfs",
+	}, imageContainer(img, "/rootfs"))
+}
+
+func TestSpec_command(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &container{args: []string{"/bin/app", "serve"}}
+	for _, tc := range []struct {
+		command    string
+		args       []string
+		expectCmd  string
+		expectArgs []string
+	}{
+		{expectCmd: "/bin/app", expectArgs: []string{"serve"}},
+		{args: []string{"check"}, expectCmd: "/bin/app", expectArgs: []string{"check"}},
+		{command: "/bin/sh", args: []string{"-c", "true"}, expectCmd: "/bin/sh", expectArgs: []string{"-c", "true"}},
+		{command: "/bin/sh", expectCmd: "/bin/sh", expectArgs: []string{}},
+	} {
+		cmd, args, err := c.command(tc.command, tc.args)
+		must.NoError(t, err)
+		must.Eq(t, tc.expectCmd, cmd)
+		must.Eq(t, tc.expectArgs, args)
+	}
+
+	_, _, err := (&container{}).command("", []string{"serve"})
+	must.ErrorContains(t, err, "command must be set")
+}
+
+func TestSpec_environment(t *testing.T) {
+	ci.Parallel(t)
+
+	c := &container{env: []string{"PATH=/bin", "HOME=/root"}}
+	cfg := &drivers.TaskConfig{Env: map[string]string{"HOME": "/home/app"}}
+	must.Eq(t, []string{"PATH=/bin", "HOME=/home/app"}, c.environment(cfg))
+}
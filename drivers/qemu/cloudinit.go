@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// cloudInitSeedName is the name of the cloud-init seed image in the task
+	// directory
+	cloudInitSeedName = "cidata.iso"
+
+	// cloudInitLabel is the volume label of NoCloud seed images
+	cloudInitLabel = "cidata"
+)
+
+// hostnameInvalidRe matches the characters not allowed in hostnames
+var hostnameInvalidRe = regexp.MustCompile(`[^a-zA-Z0-9-]`)
+
+// CloudInit is the cloud-init configuration of a task, whose files are paths
+// in the task directory, such as the destinations of templates.
+type CloudInit struct {
+	UserData      string `codec:"user_data"`
+	NetworkConfig string `codec:"network_config"`
+}
+
+// writeCloudInitSeed writes the NoCloud seed image of the task to its task
+// directory, and returns its path. The meta-data of the seed identifies the
+// instance by the allocation, so cloud-init runs again in new allocations.
+func writeCloudInitSeed(cfg *drivers.TaskConfig, ci *CloudInit) (string, error) {
+	taskDir := cfg.TaskDir().Dir
+
+	hostname := strings.Trim(hostnameInvalidRe.ReplaceAllString(cfg.Name, "-"), "-")
+	files := map[string][]byte{
+		"meta-data": fmt.Appendf(nil, "instance-id: %s\nlocal-hostname: %s\n", cfg.AllocID, hostname),
+		"user-data": nil,
+	}
+
+	for name, path := range map[string]string{
+		"user-data":      ci.UserData,
+		"network-config": ci.NetworkConfig,
+	} {
+		if path == "" {
+			continue
+		}
+		data, err := readTaskFile(taskDir, path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read cloud-init %s: %v", name, err)
+		}
+		files[name] = data
+	}
+
+	seed := filepath.Join(taskDir, cloudInitSeedName)
+	if err := writeISO(seed, cloudInitLabel, files); err != nil {
+		return "", fmt.Errorf("failed to write cloud-init seed: %v", err)
+	}
+	return seed, nil
+}
+
+// readTaskFile reads the file at path, relative to the task directory.
+func readTaskFile(taskDir, path string) ([]byte, error) {
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", path)
+	if err != nil {
+		return nil, err
+	}
+	if escapes || filepath.IsAbs(path) {
+		return nil, fmt.Errorf("%q must be a path in the task directory", path)
+	}
+	return os.ReadFile(filepath.Join(taskDir, path))
+}
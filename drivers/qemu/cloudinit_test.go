@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+// readISO returns the volume label and the files of the root directory of the
+// ISO 9660 image at path, as recorded in its Joliet volume.
+func readISO(t *testing.T, path string) (string, map[string]string) {
+	t.Helper()
+	img, err := os.ReadFile(path)
+	must.NoError(t, err)
+
+	decode := func(b []byte) string {
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(b[2*i:])
+		}
+		return string(utf16.Decode(u))
+	}
+
+	// the Joliet volume descriptor follows the primary one
+	desc := img[17*isoSectorSize : 18*isoSectorSize]
+	must.Eq(t, 2, desc[0])
+	must.Eq(t, "CD001", string(desc[1:6]))
+	must.Eq(t, "%/E", string(desc[88:91]))
+	label := decode(desc[40:72])
+
+	root := binary.LittleEndian.Uint32(desc[156+2:])
+	dir := img[root*isoSectorSize : (root+1)*isoSectorSize]
+	files := map[string]string{}
+	for len(dir) > 0 && dir[0] > 0 {
+		n := dir[0]
+		idLen := dir[32]
+		if dir[25]&2 == 0 {
+			sector := binary.LittleEndian.Uint32(dir[2:])
+			size := binary.LittleEndian.Uint32(dir[10:])
+			start := sector * isoSectorSize
+			files[decode(dir[33:33+idLen])] = string(img[start : start+size])
+		}
+		dir = dir[n:]
+	}
+	return label, files
+}
+
+func TestCloudInit_writeISO(t *testing.T) {
+	ci.Parallel(t)
+
+	large := make([]byte, 3*isoSectorSize+1)
+	for i := range large {
+		large[i] = 'x'
+	}
+
+	path := filepath.Join(t.TempDir(), "seed.iso")
+	must.NoError(t, writeISO(path, "cidata", map[string][]byte{
+		"user-data": []byte("#cloud-config\n"),
+		"meta-data": large,
+		"empty":     nil,
+	}))
+
+	label, files := readISO(t, path)
+	must.StrHasPrefix(t, "cidata", label)
+	must.Eq(t, map[string]string{
+		"user-data": "#cloud-config\n",
+		"meta-data": string(large),
+		"empty":     "",
+	}, files)
+
+	// the primary volume has ISO 9660 names
+	img, err := os.ReadFile(path)
+	must.NoError(t, err)
+	must.Eq(t, "CIDATA", string(img[16*isoSectorSize+40:16*isoSectorSize+46]))
+	must.StrContains(t, string(img[23*isoSectorSize:24*isoSectorSize]), "USER-DATA.;1")
+}
+
+func TestCloudInit_writeCloudInitSeed(t *testing.T) {
+	ci.Parallel(t)
+
+	allocDir := t.TempDir()
+	cfg := &drivers.TaskConfig{AllocID: "1234", Name: "web_vm", AllocDir: allocDir}
+	taskDir := cfg.TaskDir()
+	must.NoError(t, os.MkdirAll(taskDir.LocalDir, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(taskDir.LocalDir, "user-data"), []byte("#cloud-config\n"), 0o644))
+
+	seed, err := writeCloudInitSeed(cfg, &CloudInit{UserData: "local/user-data"})
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(taskDir.Dir, cloudInitSeedName), seed)
+
+	_, files := readISO(t, seed)
+	must.Eq(t, map[string]string{
+		"meta-data": "instance-id: 1234\nlocal-hostname: web-vm\n",
+		"user-data": "#cloud-config\n",
+	}, files)
+
+	_, err = writeCloudInitSeed(cfg, &CloudInit{NetworkConfig: "../../etc/passwd"})
+	must.ErrorContains(t, err, "must be a path in the task directory")
+}
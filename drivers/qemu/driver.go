@@ -96,13 +96,18 @@ var (
 		"guest_agent":       hclspec.NewAttr("guest_agent", "bool", false),
 		"args":              hclspec.NewAttr("args", "list(string)", false),
 		"port_map":          hclspec.NewAttr("port_map", "list(map(number))", false),
+		"alloc_mounts":      hclspec.NewAttr("alloc_mounts", "string", false),
+		"cloud_init": hclspec.NewBlock("cloud_init", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"user_data":      hclspec.NewAttr("user_data", "string", false),
+			"network_config": hclspec.NewAttr("network_config", "string", false),
+		})),
 	})
 
 	// capabilities is returned by the Capabilities RPC and indicates what
 	// optional features this driver supports
 	capabilities = &drivers.Capabilities{
 		SendSignals: false,
-		Exec:        false,
+		Exec:        true,
 		FSIsolation: fsisolation.Image,
 		NetIsolationModes: []drivers.NetIsolationMode{
 			drivers.NetIsolationModeHost,
@@ -111,7 +116,8 @@ var (
 		MountConfigs: drivers.MountConfigSupportNone,
 	}
 
-	_ drivers.DriverPlugin = (*Driver)(nil)
+	_ drivers.DriverPlugin            = (*Driver)(nil)
+	_ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
 )
 
 // TaskConfig is the driver configuration of a taskConfig within a job
@@ -123,6 +129,8 @@ type TaskConfig struct {
 	GracefulShutdown bool               `codec:"graceful_shutdown"`
 	DriveInterface   string             `codec:"drive_interface"` // Use interface for image
 	GuestAgent       bool               `codec:"guest_agent"`
+	AllocMounts      string             `codec:"alloc_mounts"` // Share the alloc, local and secrets dirs over 9p or virtiofs
+	CloudInit        *CloudInit         `codec:"cloud_init"`
 }
 
 // TaskState is the state which is encoded in the handle returned in StartTask.
@@ -319,10 +327,16 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		}
 	}
 
+	var qmpPath string
+	if path := filepath.Join(taskDir, qemuQMPSocketName); fileExists(path) {
+		qmpPath = path
+	}
+
 	h := &taskHandle{
 		exec:         execImpl,
 		pid:          taskState.Pid,
 		monitorPath:  monitorPath,
+		qmpPath:      qmpPath,
 		pluginClient: pluginClient,
 		taskConfig:   taskState.TaskConfig,
 		procState:    drivers.TaskStateRunning,
@@ -427,6 +441,10 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		return nil, nil, fmt.Errorf("image_path is not in the allowed paths")
 	}
 
+	if err := validateAllocMounts(driverConfig.AllocMounts); err != nil {
+		return nil, nil, err
+	}
+
 	// Parse configuration arguments
 	// Create the base arguments
 	accelerator := "tcg"
@@ -490,6 +508,35 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		args = append(args, "-monitor", fmt.Sprintf("unix:%s,server,nowait", monitorPath))
 	}
 
+	// The QMP socket is used to snapshot and restore the virtual machine on
+	// request of exec commands
+	var qmpPath string
+	if runtime.GOOS != "windows" {
+		qmpPath = filepath.Join(taskDir, qemuQMPSocketName)
+		if err := validateSocketPath(qmpPath); err != nil {
+			d.logger.Warn("QMP socket is unavailable, snapshots are disabled", "error", err)
+			qmpPath = ""
+		} else {
+			args = append(args, "-qmp", fmt.Sprintf("unix:%s,server,nowait", qmpPath))
+		}
+	}
+
+	if driverConfig.CloudInit != nil {
+		seed, err := writeCloudInitSeed(cfg, driverConfig.CloudInit)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, "-drive", "file="+seed+",format=raw,media=cdrom,readonly=on")
+	}
+
+	mounts := allocMountDirs(cfg.TaskDir())
+	switch driverConfig.AllocMounts {
+	case allocMounts9P:
+		args = append(args, allocMount9PArgs(mounts)...)
+	case allocMountsVirtioFS:
+		args = append(args, allocMountVirtioFSArgs(taskDir, mem, mounts)...)
+	}
+
 	if driverConfig.GuestAgent {
 		if runtime.GOOS == "windows" {
 			return nil, nil, errors.New("QEMU Guest Agent socket is unsupported on the Windows platform")
@@ -562,6 +609,21 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("starting QEMU VM command ", "args", strings.Join(args, " "))
 
+	// The virtiofsd daemons must listen before the VM starts. They're stopped
+	// if the VM fails to start, and exit on their own once it stops.
+	var virtiofsd []*exec.Cmd
+	if driverConfig.AllocMounts == allocMountsVirtioFS {
+		virtiofsd, err = startVirtiofsd(d.logger, taskDir, mounts)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	killVirtiofsd := func() {
+		for _, cmd := range virtiofsd {
+			_ = cmd.Process.Kill()
+		}
+	}
+
 	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, fmt.Sprintf("%s-executor.out", cfg.Name))
 	executorConfig := &executor.ExecutorConfig{
 		LogFile:  pluginLogFile,
@@ -573,6 +635,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
 		d.nomadConfig, executorConfig)
 	if err != nil {
+		killVirtiofsd()
 		return nil, nil, err
 	}
 
@@ -589,6 +652,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	ps, err := execImpl.Launch(execCmd)
 	if err != nil {
 		pluginClient.Kill()
+		killVirtiofsd()
 		return nil, nil, err
 	}
 	d.logger.Debug("started new QEMU VM", "id", vmID)
@@ -597,6 +661,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		exec:         execImpl,
 		pid:          ps.Pid,
 		monitorPath:  monitorPath,
+		qmpPath:      qmpPath,
 		pluginClient: pluginClient,
 		taskConfig:   cfg,
 		procState:    drivers.TaskStateRunning,
@@ -615,6 +680,7 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		execImpl.Shutdown("", 0)
 		pluginClient.Kill()
+		killVirtiofsd()
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
@@ -718,9 +784,47 @@ func (d *Driver) SignalTask(_ string, _ string) error {
 	return fmt.Errorf("QEMU driver can't signal commands")
 }
 
-func (d *Driver) ExecTask(_ string, _ []string, _ time.Duration) (*drivers.ExecTaskResult, error) {
-	return nil, fmt.Errorf("QEMU driver can't execute commands")
+// ExecTask runs the snapshot commands of the VM through its QMP socket, as the
+// driver can't execute commands in the VM.
+func (d *Driver) ExecTask(taskID string, cmd []string, _ time.Duration) (*drivers.ExecTaskResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+	if handle.qmpPath == "" {
+		return nil, fmt.Errorf("QEMU driver can't execute commands without a QMP socket")
+	}
 
+	out, err := snapshotCommand(handle.qmpPath, cmd)
+	if err != nil {
+		return &drivers.ExecTaskResult{
+			Stderr:     []byte(err.Error() + "\n"),
+			ExitResult: &drivers.ExitResult{ExitCode: 1},
+		}, nil
+	}
+	return &drivers.ExecTaskResult{
+		Stdout:     []byte(out),
+		ExitResult: &drivers.ExitResult{},
+	}, nil
+}
+
+// ExecTaskStreaming runs the snapshot commands of the VM for alloc exec
+// sessions. See ExecTask.
+func (d *Driver) ExecTaskStreaming(_ context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
+	defer opts.Stdout.Close()
+	defer opts.Stderr.Close()
+
+	result, err := d.ExecTask(taskID, opts.Command, qmpTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := opts.Stdout.Write(result.Stdout); err != nil {
+		return nil, err
+	}
+	if _, err := opts.Stderr.Write(result.Stderr); err != nil {
+		return nil, err
+	}
+	return result.ExitResult, nil
 }
 
 // GetAbsolutePath returns the absolute path of the passed binary by resolving
@@ -788,3 +892,9 @@ func sendQemuShutdown(logger hclog.Logger, monitorPath string, userPid int) erro
 	}
 	return err
 }
+
+// fileExists returns true if a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
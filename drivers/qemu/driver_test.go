@@ -287,6 +287,11 @@ config {
     https = 443
   }
   graceful_shutdown = true
+  alloc_mounts = "9p"
+  cloud_init {
+    user_data      = "local/user-data"
+    network_config = "local/network-config"
+  }
 }`
 
 	expected := &TaskConfig{
@@ -299,6 +304,11 @@ config {
 			"https": 443,
 		},
 		GracefulShutdown: true,
+		AllocMounts:      "9p",
+		CloudInit: &CloudInit{
+			UserData:      "local/user-data",
+			NetworkConfig: "local/network-config",
+		},
 	}
 
 	var tc *TaskConfig
@@ -21,6 +21,7 @@ type taskHandle struct {
 	pluginClient *plugin.Client
 	logger       hclog.Logger
 	monitorPath  string
+	qmpPath      string
 
 	// stateLock syncs access to all fields below
 	stateLock sync.RWMutex
@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	// isoSectorSize is the size of the logical sectors and blocks of ISO 9660
+	// images
+	isoSectorSize = 2048
+
+	// isoSystemAreaSectors is the number of unused sectors at the start of
+	// ISO 9660 images
+	isoSystemAreaSectors = 16
+)
+
+// isoFile is a file in the root directory of an ISO 9660 image.
+type isoFile struct {
+	name string
+	data []byte
+
+	// sector is the first sector of the data of the file
+	sector uint32
+}
+
+// isoVolume is a directory tree of an ISO 9660 image: the primary one with
+// ISO 9660 file names, or the supplementary Joliet one with Unicode names.
+type isoVolume struct {
+	joliet bool
+
+	// pathTable, pathTableBE and root are the sectors of the little and big
+	// endian path tables, and of the root directory
+	pathTable   uint32
+	pathTableBE uint32
+	root        uint32
+}
+
+// writeISO writes an ISO 9660 image with the given volume label to path,
+// holding the files in its root directory. Names are recorded as ISO 9660 file
+// names and as Joliet ones, so guests see them as is either way.
+func writeISO(path, label string, files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// layout: system area, the primary and Joliet volume descriptors, the
+	// terminator, the path tables and root directories of both volumes, and
+	// the data of the files
+	primary := &isoVolume{pathTable: 19, pathTableBE: 20, root: 23}
+	joliet := &isoVolume{joliet: true, pathTable: 21, pathTableBE: 22, root: 24}
+
+	sector := uint32(25)
+	entries := make([]*isoFile, 0, len(names))
+	for _, name := range names {
+		f := &isoFile{name: name, data: files[name], sector: sector}
+		sector += isoSectors(len(f.data))
+		entries = append(entries, f)
+	}
+	size := sector
+
+	img := make([]byte, int(size)*isoSectorSize)
+	now := time.Now().UTC()
+	for _, vol := range []*isoVolume{primary, joliet} {
+		root := vol.directory(entries, now)
+		if len(root) > isoSectorSize {
+			return fmt.Errorf("too many files for the root directory")
+		}
+		copy(img[vol.root*isoSectorSize:], root)
+
+		table := vol.pathTableRecord(binary.LittleEndian)
+		copy(img[vol.pathTable*isoSectorSize:], table)
+		copy(img[vol.pathTableBE*isoSectorSize:], vol.pathTableRecord(binary.BigEndian))
+
+		desc := vol.descriptor(label, size, uint32(len(table)), now)
+		offset := isoSystemAreaSectors * isoSectorSize
+		if vol.joliet {
+			offset += isoSectorSize
+		}
+		copy(img[offset:], desc)
+	}
+
+	// volume descriptor set terminator
+	terminator := img[(isoSystemAreaSectors+2)*isoSectorSize:]
+	terminator[0] = 255
+	copy(terminator[1:], "CD001")
+	terminator[6] = 1
+
+	for _, f := range entries {
+		copy(img[f.sector*isoSectorSize:], f.data)
+	}
+
+	return os.WriteFile(path, img, 0o644)
+}
+
+// isoSectors returns the number of sectors holding n bytes.
+func isoSectors(n int) uint32 {
+	return uint32((n + isoSectorSize - 1) / isoSectorSize)
+}
+
+// identifier returns the recorded identifier of the file name in the volume.
+func (v *isoVolume) identifier(name string) []byte {
+	if v.joliet {
+		return ucs2(name)
+	}
+	// ISO 9660 file names have an extension and a version
+	name = strings.ToUpper(name)
+	if !strings.Contains(name, ".") {
+		name += "."
+	}
+	return []byte(name + ";1")
+}
+
+// directory returns the records of the root directory of the volume.
+func (v *isoVolume) directory(files []*isoFile, now time.Time) []byte {
+	var dir []byte
+	dir = append(dir, isoDirRecord([]byte{0}, v.root, isoSectorSize, true, now)...)
+	dir = append(dir, isoDirRecord([]byte{1}, v.root, isoSectorSize, true, now)...)
+	for _, f := range files {
+		dir = append(dir, isoDirRecord(v.identifier(f.name), f.sector, uint32(len(f.data)), false, now)...)
+	}
+	return dir
+}
+
+// pathTableRecord returns the path table of the volume, which only has the
+// root directory.
+func (v *isoVolume) pathTableRecord(order binary.ByteOrder) []byte {
+	rec := make([]byte, 10)
+	rec[0] = 1
+	order.PutUint32(rec[2:], v.root)
+	order.PutUint16(rec[6:], 1)
+	return rec
+}
+
+// descriptor returns the primary or supplementary volume descriptor of the
+// volume.
+func (v *isoVolume) descriptor(label string, size, pathTableSize uint32, now time.Time) []byte {
+	desc := make([]byte, isoSectorSize)
+	desc[0] = 1
+	copy(desc[1:], "CD001")
+	desc[6] = 1
+
+	text := func(offset, length int, s string) {
+		if v.joliet {
+			b := ucs2(s)
+			for len(b) < length {
+				b = append(b, 0, ' ')
+			}
+			copy(desc[offset:offset+length], b)
+			return
+		}
+		copy(desc[offset:offset+length], fmt.Sprintf("%-*s", length, strings.ToUpper(s)))
+	}
+
+	if v.joliet {
+		desc[0] = 2
+		// escape sequence of UCS-2 level 3
+		copy(desc[88:], "%/E")
+	}
+	text(8, 32, "")
+	text(40, 32, label)
+	putBothUint32(desc[80:], size)
+	putBothUint16(desc[120:], 1)
+	putBothUint16(desc[124:], 1)
+	putBothUint16(desc[128:], isoSectorSize)
+	putBothUint32(desc[132:], pathTableSize)
+	binary.LittleEndian.PutUint32(desc[140:], v.pathTable)
+	binary.BigEndian.PutUint32(desc[148:], v.pathTableBE)
+	copy(desc[156:], isoDirRecord([]byte{0}, v.root, isoSectorSize, true, now))
+	for _, field := range [][2]int{{190, 128}, {318, 128}, {446, 128}, {574, 128}, {702, 37}, {739, 37}, {776, 37}} {
+		text(field[0], field[1], "")
+	}
+
+	stamp := now.Format("20060102150405") + "00"
+	copy(desc[813:], stamp)
+	copy(desc[830:], stamp)
+	copy(desc[847:], strings.Repeat("0", 16))
+	copy(desc[864:], stamp)
+	desc[881] = 1
+	return desc
+}
+
+// isoDirRecord returns a directory record.
+func isoDirRecord(id []byte, sector, size uint32, dir bool, now time.Time) []byte {
+	n := 33 + len(id)
+	if n%2 == 1 {
+		n++
+	}
+	rec := make([]byte, n)
+	rec[0] = byte(n)
+	putBothUint32(rec[2:], sector)
+	putBothUint32(rec[10:], size)
+	rec[18] = byte(now.Year() - 1900)
+	rec[19] = byte(now.Month())
+	rec[20] = byte(now.Day())
+	rec[21] = byte(now.Hour())
+	rec[22] = byte(now.Minute())
+	rec[23] = byte(now.Second())
+	if dir {
+		rec[25] = 2
+	}
+	putBothUint16(rec[28:], 1)
+	rec[32] = byte(len(id))
+	copy(rec[33:], id)
+	return rec
+}
+
+// putBothUint16 and putBothUint32 write the both-byte orders encoding of v,
+// little endian first.
+func putBothUint16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b, v)
+	binary.BigEndian.PutUint16(b[2:], v)
+}
+
+func putBothUint32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b, v)
+	binary.BigEndian.PutUint32(b[4:], v)
+}
+
+// ucs2 returns the big endian UCS-2 encoding of s used by Joliet.
+func ucs2(s string) []byte {
+	var b []byte
+	for _, r := range utf16.Encode([]rune(s)) {
+		b = binary.BigEndian.AppendUint16(b, r)
+	}
+	return b
+}
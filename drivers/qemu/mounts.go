@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+)
+
+const (
+	// allocMounts9P and allocMountsVirtioFS are the ways of sharing the
+	// alloc, local and secrets directories of the task with the VM
+	allocMounts9P       = "9p"
+	allocMountsVirtioFS = "virtiofs"
+
+	// virtiofsdSocketTimeout is how long the driver waits for virtiofsd to
+	// create its socket
+	virtiofsdSocketTimeout = 5 * time.Second
+)
+
+// allocMount is a directory of the task shared with the VM, which mounts it
+// by its tag.
+type allocMount struct {
+	tag  string
+	path string
+}
+
+// allocMountDirs returns the directories of the task shared with the VM.
+func allocMountDirs(taskDir *allocdir.TaskDir) []allocMount {
+	return []allocMount{
+		{tag: "alloc", path: taskDir.SharedAllocDir},
+		{tag: "local", path: taskDir.LocalDir},
+		{tag: "secrets", path: taskDir.SecretsDir},
+	}
+}
+
+// validateAllocMounts returns an error if the alloc_mounts mode isn't
+// supported on this platform.
+func validateAllocMounts(mode string) error {
+	switch mode {
+	case "":
+		return nil
+	case allocMounts9P:
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("9p alloc mounts are unsupported on the Windows platform")
+		}
+		return nil
+	case allocMountsVirtioFS:
+		if runtime.GOOS != "linux" {
+			return fmt.Errorf("virtiofs alloc mounts are only supported on Linux")
+		}
+		return nil
+	default:
+		return fmt.Errorf("alloc_mounts must be %q or %q", allocMounts9P, allocMountsVirtioFS)
+	}
+}
+
+// allocMount9PArgs returns the QEMU arguments sharing the directories with
+// the VM over 9p.
+func allocMount9PArgs(mounts []allocMount) []string {
+	var args []string
+	for _, m := range mounts {
+		id := "fs_" + m.tag
+		args = append(args,
+			"-fsdev", fmt.Sprintf("local,id=%s,path=%s,security_model=none", id, m.path),
+			"-device", fmt.Sprintf("virtio-9p-pci,fsdev=%s,mount_tag=%s", id, m.tag),
+		)
+	}
+	return args
+}
+
+// virtiofsdSocketPath returns the path of the socket of the virtiofsd daemon
+// sharing the directory with the given tag.
+func virtiofsdSocketPath(taskDir, tag string) string {
+	return filepath.Join(taskDir, "vfs-"+tag+".sock")
+}
+
+// allocMountVirtioFSArgs returns the QEMU arguments sharing the directories
+// with the VM over virtiofs. The memory of the VM must be shared with the
+// virtiofsd daemons.
+func allocMountVirtioFSArgs(taskDir, mem string, mounts []allocMount) []string {
+	args := []string{
+		"-object", fmt.Sprintf("memory-backend-memfd,id=mem,size=%s,share=on", mem),
+		"-numa", "node,memdev=mem",
+	}
+	for _, m := range mounts {
+		id := "vfs_" + m.tag
+		args = append(args,
+			"-chardev", fmt.Sprintf("socket,id=%s,path=%s", id, virtiofsdSocketPath(taskDir, m.tag)),
+			"-device", fmt.Sprintf("vhost-user-fs-pci,chardev=%s,tag=%s", id, m.tag),
+		)
+	}
+	return args
+}
+
+// startVirtiofsd starts a virtiofsd daemon for each directory, and waits for
+// their sockets. The daemons exit once the VM disconnects from them, so they
+// only have to be killed if the VM fails to start.
+func startVirtiofsd(logger hclog.Logger, taskDir string, mounts []allocMount) ([]*exec.Cmd, error) {
+	bin, err := virtiofsdPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var cmds []*exec.Cmd
+	kill := func() {
+		for _, cmd := range cmds {
+			_ = cmd.Process.Kill()
+		}
+	}
+
+	for _, m := range mounts {
+		socket := virtiofsdSocketPath(taskDir, m.tag)
+		if err := validateSocketPath(socket); err != nil {
+			kill()
+			return nil, err
+		}
+		_ = os.Remove(socket)
+
+		cmd := exec.Command(bin,
+			"--socket-path="+socket,
+			"--shared-dir="+m.path,
+			"--cache=auto",
+		)
+		if err := cmd.Start(); err != nil {
+			kill()
+			return nil, fmt.Errorf("failed to start virtiofsd for %s: %v", m.tag, err)
+		}
+		cmds = append(cmds, cmd)
+
+		// reap the daemon once it exits
+		go func(tag string) {
+			err := cmd.Wait()
+			logger.Debug("virtiofsd exited", "tag", tag, "error", err)
+		}(m.tag)
+	}
+
+	for _, m := range mounts {
+		if err := waitForSocket(virtiofsdSocketPath(taskDir, m.tag), virtiofsdSocketTimeout); err != nil {
+			kill()
+			return nil, fmt.Errorf("virtiofsd for %s did not start: %v", m.tag, err)
+		}
+	}
+	return cmds, nil
+}
+
+// virtiofsdPath returns the path of the virtiofsd binary, which distributions
+// often install outside of the PATH.
+func virtiofsdPath() (string, error) {
+	if path, err := exec.LookPath("virtiofsd"); err == nil {
+		return path, nil
+	}
+	for _, path := range []string{"/usr/libexec/virtiofsd", "/usr/lib/qemu/virtiofsd"} {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("virtiofsd binary not found")
+}
+
+// waitForSocket waits for the socket at path to exist.
+func waitForSocket(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := os.Stat(path)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/shoenig/test/must"
+)
+
+func TestMounts_validateAllocMounts(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, validateAllocMounts(""))
+	must.ErrorContains(t, validateAllocMounts("nfs"), "alloc_mounts must be")
+	if runtime.GOOS == "linux" {
+		must.NoError(t, validateAllocMounts(allocMounts9P))
+		must.NoError(t, validateAllocMounts(allocMountsVirtioFS))
+	}
+}
+
+func TestMounts_args(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg := &drivers.TaskConfig{Name: "vm", AllocDir: "/alloc/1234"}
+	mounts := allocMountDirs(cfg.TaskDir())
+
+	must.Eq(t, []string{
+		"-fsdev", "local,id=fs_alloc,path=/alloc/1234/alloc,security_model=none",
+		"-device", "virtio-9p-pci,fsdev=fs_alloc,mount_tag=alloc",
+		"-fsdev", "local,id=fs_local,path=/alloc/1234/vm/local,security_model=none",
+		"-device", "virtio-9p-pci,fsdev=fs_local,mount_tag=local",
+		"-fsdev", "local,id=fs_secrets,path=/alloc/1234/vm/secrets,security_model=none",
+		"-device", "virtio-9p-pci,fsdev=fs_secrets,mount_tag=secrets",
+	}, allocMount9PArgs(mounts))
+
+	must.Eq(t, []string{
+		"-object", "memory-backend-memfd,id=mem,size=512M,share=on",
+		"-numa", "node,memdev=mem",
+		"-chardev", "socket,id=vfs_alloc,path=/alloc/1234/vm/vfs-alloc.sock",
+		"-device", "vhost-user-fs-pci,chardev=vfs_alloc,tag=alloc",
+		"-chardev", "socket,id=vfs_local,path=/alloc/1234/vm/vfs-local.sock",
+		"-device", "vhost-user-fs-pci,chardev=vfs_local,tag=local",
+		"-chardev", "socket,id=vfs_secrets,path=/alloc/1234/vm/vfs-secrets.sock",
+		"-device", "vhost-user-fs-pci,chardev=vfs_secrets,tag=secrets",
+	}, allocMountVirtioFSArgs("/alloc/1234/vm", "512M", mounts))
+}
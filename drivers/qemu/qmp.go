@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// qemuQMPSocketName is the name of the QMP socket of the VM in the task
+	// directory. Use a short file name since socket paths have a maximum
+	// length.
+	qemuQMPSocketName = "qmp.sock"
+
+	// qmpTimeout bounds the time taken by a QMP command, as saving the state
+	// of a VM can take a while
+	qmpTimeout = 5 * time.Minute
+)
+
+// snapshotNameRe matches the valid names of snapshots
+var snapshotNameRe = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// qmpResponse is a response or an event read from a QMP socket
+type qmpResponse struct {
+	Return json.RawMessage `json:"return"`
+	Error  *struct {
+		Class string `json:"class"`
+		Desc  string `json:"desc"`
+	} `json:"error"`
+	Event string `json:"event"`
+}
+
+// qmpExecute runs the QMP command on the VM whose QMP socket is at path, and
+// returns the result of the command.
+func qmpExecute(path, command string, args any) (json.RawMessage, error) {
+	conn, err := net.DialTimeout("unix", path, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to QMP socket: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(qmpTimeout)); err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bufio.NewReader(conn))
+	enc := json.NewEncoder(conn)
+
+	// read the greeting and leave capabilities negotiation mode
+	var greeting map[string]json.RawMessage
+	if err := dec.Decode(&greeting); err != nil {
+		return nil, fmt.Errorf("failed to read QMP greeting: %v", err)
+	}
+	if _, err := qmpCall(dec, enc, "qmp_capabilities", nil); err != nil {
+		return nil, err
+	}
+	return qmpCall(dec, enc, command, args)
+}
+
+// qmpCall sends a command and returns its response, skipping events.
+func qmpCall(dec *json.Decoder, enc *json.Encoder, command string, args any) (json.RawMessage, error) {
+	req := map[string]any{"execute": command}
+	if args != nil {
+		req["arguments"] = args
+	}
+	if err := enc.Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send QMP command %q: %v", command, err)
+	}
+
+	for {
+		var resp qmpResponse
+		if err := dec.Decode(&resp); err != nil {
+			return nil, fmt.Errorf("failed to read QMP response to %q: %v", command, err)
+		}
+		switch {
+		case resp.Event != "":
+			continue
+		case resp.Error != nil:
+			return nil, fmt.Errorf("QMP command %q failed: %s: %s", command, resp.Error.Class, resp.Error.Desc)
+		default:
+			return resp.Return, nil
+		}
+	}
+}
+
+// qmpMonitorCommand runs the human monitor command line on the VM and
+// returns its output.
+func qmpMonitorCommand(path, commandLine string) (string, error) {
+	ret, err := qmpExecute(path, "human-monitor-command", map[string]string{
+		"command-line": commandLine,
+	})
+	if err != nil {
+		return "", err
+	}
+	var out string
+	if err := json.Unmarshal(ret, &out); err != nil {
+		return "", fmt.Errorf("failed to decode monitor output: %v", err)
+	}
+	return out, nil
+}
+
+// snapshotCommand runs the snapshot command of the exec arguments on the VM
+// whose QMP socket is at path, and returns its output. Commands are:
+//
+//	snapshot list
+//	snapshot save <name>
+//	snapshot restore <name>
+//	snapshot delete <name>
+//
+// Snapshots hold the state of the VM and of its disks, which must be qcow2
+// images.
+func snapshotCommand(path string, args []string) (string, error) {
+	const usage = "usage: snapshot list | snapshot save|restore|delete <name>"
+	if len(args) < 2 || args[0] != "snapshot" {
+		return "", fmt.Errorf("unsupported command; %s", usage)
+	}
+
+	if args[1] == "list" {
+		if len(args) != 2 {
+			return "", fmt.Errorf(usage)
+		}
+		return qmpMonitorCommand(path, "info snapshots")
+	}
+
+	if len(args) != 3 {
+		return "", fmt.Errorf(usage)
+	}
+	name := args[2]
+	if !snapshotNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid snapshot name %q", name)
+	}
+
+	var commandLine string
+	switch args[1] {
+	case "save":
+		commandLine = "savevm " + name
+	case "restore":
+		commandLine = "loadvm " + name
+	case "delete":
+		commandLine = "delvm " + name
+	default:
+		return "", fmt.Errorf("unsupported snapshot command %q; %s", args[1], usage)
+	}
+
+	// these monitor commands only print errors
+	out, err := qmpMonitorCommand(path, commandLine)
+	if err != nil {
+		return "", err
+	}
+	if out = strings.TrimSpace(out); out != "" {
+		return "", fmt.Errorf("failed to %s snapshot %q: %s", args[1], name, out)
+	}
+	return "", nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package qemu
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+// testQMPServer serves a QMP socket answering human monitor commands with
+// the output of the given function, and returns the path of the socket and
+// the channel of the received command lines.
+func testQMPServer(t *testing.T, output func(commandLine string) string) (string, <-chan string) {
+	t.Helper()
+
+	// socket paths have a maximum length, so avoid the long test directories
+	dir, err := os.MkdirTemp("", "qmp")
+	must.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, qemuQMPSocketName)
+
+	l, err := net.Listen("unix", path)
+	must.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	commands := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				dec := json.NewDecoder(conn)
+				enc := json.NewEncoder(conn)
+				_ = enc.Encode(map[string]any{"QMP": map[string]any{"version": map[string]any{}}})
+
+				for {
+					var req struct {
+						Execute   string            `json:"execute"`
+						Arguments map[string]string `json:"arguments"`
+					}
+					if err := dec.Decode(&req); err != nil {
+						return
+					}
+					switch req.Execute {
+					case "qmp_capabilities":
+						_ = enc.Encode(map[string]any{"return": map[string]any{}})
+					case "human-monitor-command":
+						line := req.Arguments["command-line"]
+						commands <- line
+						// events may be sent before the response
+						_ = enc.Encode(map[string]any{"event": "STOP"})
+						_ = enc.Encode(map[string]any{"return": output(line)})
+					default:
+						_ = enc.Encode(map[string]any{"error": map[string]any{
+							"class": "CommandNotFound",
+							"desc":  "The command " + req.Execute + " has not been found",
+						}})
+					}
+				}
+			}()
+		}
+	}()
+	return path, commands
+}
+
+func TestQMP_snapshotCommand(t *testing.T) {
+	ci.Parallel(t)
+
+	path, commands := testQMPServer(t, func(line string) string {
+		switch line {
+		case "info snapshots":
+			return "List of snapshots present on all disks:\r\nID  TAG\r\n--  before\r\n"
+		case "loadvm missing":
+			return "Error: Snapshot 'missing' does not exist in one or more devices\r\n"
+		}
+		return ""
+	})
+
+	out, err := snapshotCommand(path, []string{"snapshot", "save", "before"})
+	must.NoError(t, err)
+	must.Eq(t, "", out)
+	must.Eq(t, "savevm before", <-commands)
+
+	out, err = snapshotCommand(path, []string{"snapshot", "list"})
+	must.NoError(t, err)
+	must.StrContains(t, out, "before")
+	must.Eq(t, "info snapshots", <-commands)
+
+	_, err = snapshotCommand(path, []string{"snapshot", "restore", "missing"})
+	must.ErrorContains(t, err, `failed to restore snapshot "missing": Error: Snapshot 'missing' does not exist`)
+	must.Eq(t, "loadvm missing", <-commands)
+
+	_, err = snapshotCommand(path, []string{"snapshot", "delete", "before"})
+	must.NoError(t, err)
+	must.Eq(t, "delvm before", <-commands)
+
+	// invalid commands never reach the VM
+	_, err = snapshotCommand(path, []string{"snapshot", "save", "a; quit"})
+	must.ErrorContains(t, err, "invalid snapshot name")
+	_, err = snapshotCommand(path, []string{"snapshot", "revert", "before"})
+	must.ErrorContains(t, err, "unsupported snapshot command")
+	_, err = snapshotCommand(path, []string{"/bin/sh"})
+	must.ErrorContains(t, err, "unsupported command")
+	must.Eq(t, 0, len(commands))
+}
+
+func TestQMP_qmpExecute_error(t *testing.T) {
+	ci.Parallel(t)
+
+	path, _ := testQMPServer(t, func(string) string { return "" })
+	_, err := qmpExecute(path, "query-unknown", nil)
+	must.ErrorContains(t, err, "CommandNotFound")
+}
@@ -149,6 +149,28 @@ type ExecCommand struct {
 	// ModeIPC is the IPC isolation mode (private or host).
 	ModeIPC string
 
+	// ModeUserNS is the user namespace isolation mode (private or host). In
+	// private mode, the IDs of the task from 0 to UserNSIDCount are mapped to
+	// the host IDs starting at UserNSIDStart.
+	ModeUserNS string
+
+	// UserNSIDStart is the first host UID and GID of the user namespace.
+	UserNSIDStart uint32
+
+	// UserNSIDCount is the number of IDs mapped in the user namespace.
+	UserNSIDCount uint32
+
+	// ImageRootfs is the host path of the root filesystem unpacked from the
+	// OCI image of the task. If set, it's the root of the container instead
+	// of the task directory, whose alloc, local, secrets and tmp directories
+	// are bind mounted into it.
+	ImageRootfs string
+
+	// WorkDir is the working directory of the process launched by the
+	// libcontainer executor, in its root filesystem. Defaults to the root
+	// directory if unset.
+	WorkDir string
+
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
 
@@ -167,6 +189,16 @@ type ExecCommand struct {
 	// OOMScoreAdj allows setting oom_score_adj (likelihood of process being
 	// OOM killed) on Linux systems
 	OOMScoreAdj int32
+
+	// SeccompProfile is the seccomp profile applied to the process by the
+	// libcontainer executor: "default", "unconfined", or the path of a JSON
+	// profile on the host. Empty means unconfined.
+	SeccompProfile string
+
+	// LandlockPaths restricts the filesystem access of the process launched
+	// by the libcontainer executor to these paths of its chroot, in the
+	// "type:mode:path" format. Landlock is not applied if empty.
+	LandlockPaths []string
 }
 
 func (c *ExecCommand) getCgroupOr(controller, fallback string) string {
@@ -15,12 +15,14 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/armon/circbuf"
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/hashicorp/consul-template/signals"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-set/v2"
@@ -30,6 +32,8 @@ import (
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/drivers/shared/capabilities"
 	"github.com/hashicorp/nomad/drivers/shared/executor/procstats"
+	"github.com/hashicorp/nomad/helper/users"
+	"github.com/hashicorp/nomad/helper/users/dynamic"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
@@ -39,6 +43,7 @@ import (
 	"github.com/opencontainers/runc/libcontainer/devices"
 	ldevices "github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runc/libcontainer/specconv"
+	luser "github.com/opencontainers/runc/libcontainer/user"
 	lutils "github.com/opencontainers/runc/libcontainer/utils"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"golang.org/x/sys/unix"
@@ -199,6 +204,9 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}
 
 	combined := append([]string{taskPath}, command.Args...)
+	if len(command.LandlockPaths) > 0 {
+		combined = landlockArgs(command.LandlockPaths, combined)
+	}
 	stdout, err := command.Stdout()
 	if err != nil {
 		return nil, err
@@ -214,6 +222,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	process := &libcontainer.Process{
 		Args:   combined,
 		Env:    command.Env,
+		Cwd:    command.WorkDir,
 		Stdout: stdout,
 		Stderr: stderr,
 		Init:   true,
@@ -223,6 +232,17 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		process.User = command.User
 	}
 
+	if command.ModeUserNS == IsolationModePrivate {
+		uid, gid, user, err := userNSOwner(command)
+		if err != nil {
+			return nil, err
+		}
+		if err := allocdir.ChownUserNS(command.TaskDir, uid, gid); err != nil {
+			return nil, err
+		}
+		process.User = user
+	}
+
 	l.userProc = process
 
 	l.totalCpuStats = cpustats.New(l.compute)
@@ -644,6 +664,11 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 	// set the new root directory for the container
 	cfg.Rootfs = command.TaskDir
 
+	// tasks with an image run in its root filesystem instead
+	if command.ImageRootfs != "" {
+		cfg.Rootfs = command.ImageRootfs
+	}
+
 	// disable pivot_root if set in the driver's configuration
 	cfg.NoPivotRoot = command.NoPivotRoot
 
@@ -723,6 +748,10 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 		},
 	}
 
+	if command.ImageRootfs != "" {
+		cfg.Mounts = append(cfg.Mounts, imageTaskDirMounts(command.TaskDir)...)
+	}
+
 	if len(command.Mounts) > 0 {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
@@ -730,6 +759,126 @@ func configureIsolation(cfg *runc.Config, command *ExecCommand) error {
 	return nil
 }
 
+// imageTaskDirs are the directories of the task directory bind mounted into
+// the root filesystem of an image, where they are found in the chroot of
+// tasks without an image.
+var imageTaskDirs = []string{allocdir.SharedAllocName, allocdir.TaskLocal, allocdir.TaskSecrets, allocdir.TmpDirName}
+
+// imageTaskDirMounts returns the bind mounts of the imageTaskDirs.
+func imageTaskDirMounts(taskDir string) []*runc.Mount {
+	mounts := make([]*runc.Mount, 0, len(imageTaskDirs))
+	for _, dir := range imageTaskDirs {
+		mounts = append(mounts, &runc.Mount{
+			Source:      filepath.Join(taskDir, dir),
+			Destination: "/" + dir,
+			Device:      "bind",
+			Flags:       unix.MS_BIND | unix.MS_REC,
+		})
+	}
+	return mounts
+}
+
+// configureSecurity applies the seccomp profile of the task, and mounts the
+// Landlock shim if the task is restricted with Landlock.
+func configureSecurity(cfg *runc.Config, command *ExecCommand) error {
+	seccomp, err := seccompConfig(command.SeccompProfile)
+	if err != nil {
+		return err
+	}
+	cfg.Seccomp = seccomp
+
+	if len(command.LandlockPaths) > 0 {
+		mount, err := landlockMount()
+		if err != nil {
+			return err
+		}
+		cfg.Mounts = append(cfg.Mounts, mount)
+	}
+	return nil
+}
+
+// configureUserNamespace runs the task in a user namespace if enabled, mapping
+// the IDs of the task from 0 to UserNSIDCount to the host IDs starting at
+// UserNSIDStart. The ID of a dynamic workload user is mapped to itself, as it
+// is outside of the subordinate range.
+func configureUserNamespace(cfg *runc.Config, command *ExecCommand) error {
+	if command.ModeUserNS != IsolationModePrivate {
+		return nil
+	}
+	if command.UserNSIDStart == 0 || command.UserNSIDCount == 0 {
+		return fmt.Errorf("user namespace requires userns_id_start and userns_id_count to be set")
+	}
+
+	mappings := []runc.IDMap{{
+		ContainerID: 0,
+		HostID:      int64(command.UserNSIDStart),
+		Size:        int64(command.UserNSIDCount),
+	}}
+	if ugid, err := dynamic.Parse(command.User); err == nil {
+		id := int64(ugid)
+		if id < int64(command.UserNSIDCount) ||
+			(id >= int64(command.UserNSIDStart) && id < int64(command.UserNSIDStart)+int64(command.UserNSIDCount)) {
+			return fmt.Errorf("dynamic workload user %d overlaps the user namespace ID range", id)
+		}
+		mappings = append(mappings, runc.IDMap{ContainerID: id, HostID: id, Size: 1})
+	}
+
+	cfg.Namespaces = append(cfg.Namespaces, runc.Namespace{Type: runc.NEWUSER})
+	cfg.UidMappings = mappings
+	cfg.GidMappings = mappings
+
+	// sysfs and mqueue can only be mounted by the owner of the network and
+	// IPC namespaces, so they are bind mounted from the host instead when
+	// the task shares them with the host
+	for _, m := range cfg.Mounts {
+		switch {
+		case m.Device == "sysfs" && command.NetworkIsolation == nil:
+			m.Source = "/sys"
+			m.Device = "bind"
+			m.Flags = syscall.MS_BIND | syscall.MS_REC | syscall.MS_RDONLY | syscall.MS_NOSUID | syscall.MS_NOEXEC | syscall.MS_NODEV
+		case m.Device == "mqueue" && command.ModeIPC != IsolationModePrivate:
+			m.Source = "/dev/mqueue"
+			m.Device = "bind"
+			m.Flags = syscall.MS_BIND | syscall.MS_NOSUID | syscall.MS_NOEXEC | syscall.MS_NODEV
+		}
+	}
+	return nil
+}
+
+// userNSOwner returns the host UID and GID of the task user when running in a
+// user namespace, and the user to run the task process as.
+func userNSOwner(command *ExecCommand) (int, int, string, error) {
+	if ugid, err := dynamic.Parse(command.User); err == nil {
+		// dynamic workload users don't exist in the chroot's passwd file
+		return int(ugid), int(ugid), fmt.Sprintf("%d:%d", ugid, ugid), nil
+	}
+
+	uid, gid := 0, 0
+	switch {
+	case command.User == "":
+	case command.ImageRootfs != "":
+		// users of images are defined by the image's own passwd file
+		passwd, _ := securejoin.SecureJoin(command.ImageRootfs, "/etc/passwd")
+		group, _ := securejoin.SecureJoin(command.ImageRootfs, "/etc/group")
+		u, err := luser.GetExecUserPath(command.User, nil, passwd, group)
+		if err != nil {
+			return 0, 0, "", err
+		}
+		uid, gid = u.Uid, u.Gid
+	default:
+		var err error
+		uid, gid, _, err = users.LookupUnix(command.User)
+		if err != nil {
+			return 0, 0, "", err
+		}
+	}
+	if uint32(uid) >= command.UserNSIDCount || uint32(gid) >= command.UserNSIDCount {
+		return 0, 0, "", fmt.Errorf("user %q is not mapped in the user namespace", command.User)
+	}
+	start := int(command.UserNSIDStart)
+	return start + uid, start + gid, command.User, nil
+}
+
 func (l *LibcontainerExecutor) configureCgroups(cfg *runc.Config, command *ExecCommand) error {
 	// note: an alloc TR hook pre-creates the cgroup(s) in both v1 and v2
 
@@ -858,6 +1007,14 @@ func (l *LibcontainerExecutor) newLibcontainerConfig(command *ExecCommand) (*run
 		return nil, err
 	}
 
+	if err := configureSecurity(cfg, command); err != nil {
+		return nil, err
+	}
+
+	if err := configureUserNamespace(cfg, command); err != nil {
+		return nil, err
+	}
+
 	if err := l.configureCgroups(cfg, command); err != nil {
 		return nil, err
 	}
@@ -941,9 +1098,10 @@ func cmdMounts(mounts []*drivers.MountConfig) []*runc.Mount {
 
 // lookupTaskBin finds the file `bin`, searching in order:
 //   - taskDir/local
-//   - taskDir
+//   - taskDir, or the root filesystem of the task's image
 //   - each mount, in order listed in the jobspec
 //   - a PATH-like search of usr/local/bin/, usr/bin/, and bin/ inside the taskDir
+//     or the image
 //
 // Returns an absolute path inside the container that will get passed as arg[0]
 // to the launched process, and the absolute path to that binary as seen by the
@@ -961,8 +1119,12 @@ func lookupTaskBin(command *ExecCommand) (string, string, error) {
 		return taskPath, hostPath, nil
 	}
 
-	// Check at the root of the task's directory
-	taskPath, hostPath, err = getPathInTaskDir(command.TaskDir, command.TaskDir, bin)
+	// Check at the root of the task's directory, or of its image
+	if command.ImageRootfs != "" && !inImageTaskDir(bin) {
+		taskPath, hostPath, err = getPathInRootfs(command.ImageRootfs, bin)
+	} else {
+		taskPath, hostPath, err = getPathInTaskDir(command.TaskDir, command.TaskDir, bin)
+	}
 	if err == nil {
 		return taskPath, hostPath, nil
 	}
@@ -992,8 +1154,12 @@ func lookupTaskBin(command *ExecCommand) (string, string, error) {
 	restrictedPaths := []string{"/usr/local/bin", "/usr/bin", "/bin"}
 
 	for _, dir := range restrictedPaths {
-		pathDir := filepath.Join(command.TaskDir, dir)
-		taskPath, hostPath, err = getPathInTaskDir(command.TaskDir, pathDir, bin)
+		if command.ImageRootfs != "" {
+			taskPath, hostPath, err = getPathInRootfs(command.ImageRootfs, filepath.Join(dir, bin))
+		} else {
+			pathDir := filepath.Join(command.TaskDir, dir)
+			taskPath, hostPath, err = getPathInTaskDir(command.TaskDir, pathDir, bin)
+		}
 		if err == nil {
 			return taskPath, hostPath, nil
 		}
@@ -1029,6 +1195,27 @@ func getPathInTaskDir(taskDir, searchDir, bin string) (string, string, error) {
 	return filepath.Clean("/" + rel), hostPath, nil
 }
 
+// inImageTaskDir returns whether the path is in one of the imageTaskDirs.
+func inImageTaskDir(path string) bool {
+	first, _, _ := strings.Cut(strings.TrimPrefix(filepath.Clean("/"+path), "/"), "/")
+	return slices.Contains(imageTaskDirs, first)
+}
+
+// getPathInRootfs searches for the binary in the root filesystem of an image.
+// Symlinks are resolved within the root filesystem, as images commonly link
+// binaries with absolute paths that would escape it on the host. It returns
+// the absolute path inside the container and the resolved path on the host.
+func getPathInRootfs(rootfs, bin string) (string, string, error) {
+	hostPath, err := securejoin.SecureJoin(rootfs, bin)
+	if err != nil {
+		return "", "", err
+	}
+	if err := filepathIsRegular(hostPath); err != nil {
+		return "", "", err
+	}
+	return filepath.Clean("/" + bin), hostPath, nil
+}
+
 // getPathInMount for the binary in the mount's host path, constructing the path
 // considering that the bin path is rooted in the mount's task path and not its
 // host path. It returns the absolute path rooted inside the container and the
@@ -138,6 +138,97 @@ func TestExecutor_configureNamespaces(t *testing.T) {
 	})
 }
 
+func TestExecutor_configureUserNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	newConfig := func() *lconfigs.Config {
+		return &lconfigs.Config{Mounts: []*lconfigs.Mount{
+			{Source: "sysfs", Destination: "/sys", Device: "sysfs"},
+			{Source: "mqueue", Destination: "/dev/mqueue", Device: "mqueue"},
+		}}
+	}
+
+	t.Run("host", func(t *testing.T) {
+		cfg := newConfig()
+		must.NoError(t, configureUserNamespace(cfg, &ExecCommand{ModeUserNS: "host"}))
+		must.SliceEmpty(t, cfg.Namespaces)
+		must.SliceEmpty(t, cfg.UidMappings)
+	})
+
+	t.Run("private", func(t *testing.T) {
+		cfg := newConfig()
+		must.NoError(t, configureUserNamespace(cfg, &ExecCommand{
+			ModeUserNS:    "private",
+			ModeIPC:       "private",
+			UserNSIDStart: 100000,
+			UserNSIDCount: 65536,
+		}))
+		must.Eq(t, lconfigs.Namespaces{{Type: lconfigs.NEWUSER}}, cfg.Namespaces)
+		must.Eq(t, []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}, cfg.UidMappings)
+		must.Eq(t, cfg.UidMappings, cfg.GidMappings)
+
+		// sysfs is bind mounted without a network namespace
+		must.Eq(t, "bind", cfg.Mounts[0].Device)
+		must.Eq(t, "/sys", cfg.Mounts[0].Source)
+		must.Eq(t, "mqueue", cfg.Mounts[1].Device)
+	})
+
+	t.Run("dynamic user", func(t *testing.T) {
+		cfg := newConfig()
+		must.NoError(t, configureUserNamespace(cfg, &ExecCommand{
+			User:          "nomad-80001",
+			ModeUserNS:    "private",
+			UserNSIDStart: 100000,
+			UserNSIDCount: 65536,
+		}))
+		must.Eq(t, []lconfigs.IDMap{
+			{ContainerID: 0, HostID: 100000, Size: 65536},
+			{ContainerID: 80001, HostID: 80001, Size: 1},
+		}, cfg.UidMappings)
+		must.Eq(t, "/dev/mqueue", cfg.Mounts[1].Source)
+	})
+
+	t.Run("dynamic user overlaps", func(t *testing.T) {
+		err := configureUserNamespace(newConfig(), &ExecCommand{
+			User:          "nomad-80001",
+			ModeUserNS:    "private",
+			UserNSIDStart: 70000,
+			UserNSIDCount: 65536,
+		})
+		must.ErrorContains(t, err, "overlaps the user namespace ID range")
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		err := configureUserNamespace(newConfig(), &ExecCommand{ModeUserNS: "private"})
+		must.ErrorContains(t, err, "requires userns_id_start and userns_id_count")
+	})
+}
+
+func TestExecutor_userNSOwner(t *testing.T) {
+	ci.Parallel(t)
+
+	command := &ExecCommand{UserNSIDStart: 100000, UserNSIDCount: 65536}
+
+	command.User = "root"
+	uid, gid, user, err := userNSOwner(command)
+	must.NoError(t, err)
+	must.Eq(t, 100000, uid)
+	must.Eq(t, 100000, gid)
+	must.Eq(t, "root", user)
+
+	command.User = "nomad-80001"
+	uid, gid, user, err = userNSOwner(command)
+	must.NoError(t, err)
+	must.Eq(t, 80001, uid)
+	must.Eq(t, 80001, gid)
+	must.Eq(t, "80001:80001", user)
+
+	command.User = "nobody"
+	command.UserNSIDCount = 1000
+	_, _, _, err = userNSOwner(command)
+	must.ErrorContains(t, err, "is not mapped in the user namespace")
+}
+
 func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
@@ -485,6 +576,42 @@ func TestExecutor_LookupTaskBin(t *testing.T) {
 	}
 }
 
+func TestExecutor_LookupTaskBin_image(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	rootfs := t.TempDir()
+	cmd := &ExecCommand{TaskDir: taskDir, ImageRootfs: rootfs}
+
+	must.NoError(t, os.MkdirAll(filepath.Join(taskDir, "local"), 0o700))
+	must.NoError(t, os.MkdirAll(filepath.Join(rootfs, "bin"), 0o700))
+	must.NoError(t, os.WriteFile(filepath.Join(taskDir, "local", "app"), []byte("hello"), 0o700))
+	must.NoError(t, os.WriteFile(filepath.Join(rootfs, "bin", "busybox"), []byte("hello"), 0o700))
+
+	// absolute symlinks are resolved in the image, not on the host
+	must.NoError(t, os.Symlink("/bin/busybox", filepath.Join(rootfs, "bin", "sh")))
+
+	for _, tc := range []struct {
+		cmd            string
+		expectTaskPath string
+		expectHostPath string
+	}{
+		{cmd: "sh", expectTaskPath: "/bin/sh", expectHostPath: filepath.Join(rootfs, "bin", "busybox")},
+		{cmd: "/bin/sh", expectTaskPath: "/bin/sh", expectHostPath: filepath.Join(rootfs, "bin", "busybox")},
+		{cmd: "/local/app", expectTaskPath: "/local/app", expectHostPath: filepath.Join(taskDir, "local", "app")},
+	} {
+		cmd.Cmd = tc.cmd
+		taskPath, hostPath, err := lookupTaskBin(cmd)
+		must.NoError(t, err)
+		must.Eq(t, tc.expectTaskPath, taskPath)
+		must.Eq(t, tc.expectHostPath, hostPath)
+	}
+
+	cmd.Cmd = "/etc/passwd"
+	_, _, err := lookupTaskBin(cmd)
+	must.Error(t, err)
+}
+
 // Exec Launch looks for the binary only inside the chroot
 func TestExecutor_EscapeContainer(t *testing.T) {
 	ci.Parallel(t)
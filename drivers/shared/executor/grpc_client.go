@@ -55,6 +55,13 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		CgroupV2Override: cmd.OverrideCgroupV2,
 		CgroupV1Override: cmd.OverrideCgroupV1,
 		OomScoreAdj:      cmd.OOMScoreAdj,
+		SeccompProfile:   cmd.SeccompProfile,
+		LandlockPaths:    cmd.LandlockPaths,
+		UsernsMode:       cmd.ModeUserNS,
+		UsernsIdStart:    cmd.UserNSIDStart,
+		UsernsIdCount:    cmd.UserNSIDCount,
+		ImageRootfs:      cmd.ImageRootfs,
+		WorkDir:          cmd.WorkDir,
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -44,6 +44,13 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		OverrideCgroupV2: req.CgroupV2Override,
 		OverrideCgroupV1: req.CgroupV1Override,
 		OOMScoreAdj:      req.OomScoreAdj,
+		SeccompProfile:   req.SeccompProfile,
+		LandlockPaths:    req.LandlockPaths,
+		ModeUserNS:       req.UsernsMode,
+		UserNSIDStart:    req.UsernsIdStart,
+		UserNSIDCount:    req.UsernsIdCount,
+		ImageRootfs:      req.ImageRootfs,
+		WorkDir:          req.WorkDir,
 	})
 
 	if err != nil {
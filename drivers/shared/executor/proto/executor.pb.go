@@ -49,6 +49,13 @@ type LaunchRequest struct {
 	CgroupV2Override     string                       `protobuf:"bytes,20,opt,name=cgroup_v2_override,json=cgroupV2Override,proto3" json:"cgroup_v2_override,omitempty"`
 	CgroupV1Override     map[string]string            `protobuf:"bytes,21,rep,name=cgroup_v1_override,json=cgroupV1Override,proto3" json:"cgroup_v1_override,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	OomScoreAdj          int32                        `protobuf:"varint,22,opt,name=oom_score_adj,json=oomScoreAdj,proto3" json:"oom_score_adj,omitempty"`
+	SeccompProfile       string                       `protobuf:"bytes,23,opt,name=seccomp_profile,json=seccompProfile,proto3" json:"seccomp_profile,omitempty"`
+	LandlockPaths        []string                     `protobuf:"bytes,24,rep,name=landlock_paths,json=landlockPaths,proto3" json:"landlock_paths,omitempty"`
+	UsernsMode           string                       `protobuf:"bytes,25,opt,name=userns_mode,json=usernsMode,proto3" json:"userns_mode,omitempty"`
+	UsernsIdStart        uint32                       `protobuf:"varint,26,opt,name=userns_id_start,json=usernsIdStart,proto3" json:"userns_id_start,omitempty"`
+	UsernsIdCount        uint32                       `protobuf:"varint,27,opt,name=userns_id_count,json=usernsIdCount,proto3" json:"userns_id_count,omitempty"`
+	ImageRootfs          string                       `protobuf:"bytes,28,opt,name=image_rootfs,json=imageRootfs,proto3" json:"image_rootfs,omitempty"`
+	WorkDir              string                       `protobuf:"bytes,29,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -234,6 +241,55 @@ func (m *LaunchRequest) GetOomScoreAdj() int32 {
 	return 0
 }
 
+func (m *LaunchRequest) GetSeccompProfile() string {
+	if m != nil {
+		return m.SeccompProfile
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetLandlockPaths() []string {
+	if m != nil {
+		return m.LandlockPaths
+	}
+	return nil
+}
+
+func (m *LaunchRequest) GetUsernsMode() string {
+	if m != nil {
+		return m.UsernsMode
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUsernsIdStart() uint32 {
+	if m != nil {
+		return m.UsernsIdStart
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetUsernsIdCount() uint32 {
+	if m != nil {
+		return m.UsernsIdCount
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetImageRootfs() string {
+	if m != nil {
+		return m.ImageRootfs
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetWorkDir() string {
+	if m != nil {
+		return m.WorkDir
+	}
+	return ""
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -908,82 +964,89 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1187 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x7d, 0x6f, 0xdb, 0x44,
-	0x1c, 0x9e, 0x9b, 0xa6, 0x49, 0x7e, 0x49, 0xda, 0xec, 0xd8, 0x8a, 0x17, 0x84, 0x56, 0x8c, 0xc4,
-	0x22, 0x18, 0xee, 0xd6, 0xbd, 0x0a, 0x24, 0x06, 0xeb, 0x06, 0x9a, 0xf6, 0x42, 0xe5, 0x8e, 0x4d,
-	0xe2, 0x0f, 0xcc, 0xcd, 0xbe, 0x25, 0xb7, 0x38, 0x3e, 0x73, 0x77, 0xce, 0x5a, 0x09, 0x89, 0x2f,
-	0x01, 0x12, 0x1f, 0x80, 0x4f, 0xc4, 0x27, 0x42, 0xf7, 0xe6, 0x26, 0xdd, 0x98, 0xdc, 0x22, 0xfe,
-	0xca, 0xdd, 0xe3, 0xe7, 0xf7, 0x7e, 0xf7, 0x5c, 0xe0, 0x72, 0xca, 0xe9, 0x9c, 0x70, 0xb1, 0x2d,
-	0x26, 0x98, 0x93, 0x74, 0x9b, 0x1c, 0x90, 0xa4, 0x94, 0x8c, 0x6f, 0x17, 0x9c, 0x49, 0x56, 0x6d,
-	0x43, 0xbd, 0x45, 0x9f, 0x4c, 0xb0, 0x98, 0xd0, 0x84, 0xf1, 0x22, 0xcc, 0xd9, 0x0c, 0xa7, 0x61,
-	0x91, 0x95, 0x63, 0x9a, 0x8b, 0x70, 0x99, 0x37, 0xbc, 0x38, 0x66, 0x6c, 0x9c, 0x11, 0xe3, 0xe4,
-	0x45, 0xf9, 0x72, 0x5b, 0xd2, 0x19, 0x11, 0x12, 0xcf, 0x0a, 0x4b, 0x08, 0xac, 0xe1, 0xb6, 0x0b,
-	0x6f, 0xc2, 0x99, 0x9d, 0xe1, 0x04, 0x7f, 0xb7, 0xa1, 0xff, 0x08, 0x97, 0x79, 0x32, 0x89, 0xc8,
-	0x2f, 0x25, 0x11, 0x12, 0x0d, 0xa0, 0x91, 0xcc, 0x52, 0xdf, 0xdb, 0xf2, 0x46, 0x9d, 0x48, 0x2d,
-	0x11, 0x82, 0x55, 0xcc, 0xc7, 0xc2, 0x5f, 0xd9, 0x6a, 0x8c, 0x3a, 0x91, 0x5e, 0xa3, 0x27, 0xd0,
-	0xe1, 0x44, 0xb0, 0x92, 0x27, 0x44, 0xf8, 0x8d, 0x2d, 0x6f, 0xd4, 0xdd, 0xb9, 0x12, 0xfe, 0x5b,
-	0xe2, 0x36, 0xbe, 0x09, 0x19, 0x46, 0xce, 0x2e, 0x3a, 0x72, 0x81, 0x2e, 0x42, 0x57, 0xc8, 0x94,
-	0x95, 0x32, 0x2e, 0xb0, 0x9c, 0xf8, 0xab, 0x3a, 0x3a, 0x18, 0x68, 0x0f, 0xcb, 0x89, 0x25, 0x10,
-	0xce, 0x0d, 0xa1, 0x59, 0x11, 0x08, 0xe7, 0x9a, 0x30, 0x80, 0x06, 0xc9, 0xe7, 0xfe, 0x9a, 0x4e,
-	0x52, 0x2d, 0x55, 0xde, 0xa5, 0x20, 0xdc, 0x6f, 0x69, 0xae, 0x5e, 0xa3, 0x0b, 0xd0, 0x96, 0x58,
-	0x4c, 0xe3, 0x94, 0x72, 0xbf, 0xad, 0xf1, 0x96, 0xda, 0xdf, 0xa3, 0x1c, 0x5d, 0x82, 0x0d, 0x97,
-	0x4f, 0x9c, 0xd1, 0x19, 0x95, 0xc2, 0xef, 0x6c, 0x79, 0xa3, 0x76, 0xb4, 0xee, 0xe0, 0x47, 0x1a,
-	0x45, 0xd7, 0xe1, 0xdc, 0x0b, 0x2c, 0x68, 0x12, 0x17, 0x9c, 0x25, 0x44, 0x88, 0x38, 0x19, 0x73,
-	0x56, 0x16, 0x3e, 0x28, 0xf6, 0xdd, 0x15, 0xdf, 0x8b, 0x90, 0xfe, 0xbe, 0x67, 0x3e, 0xef, 0xea,
-	0xaf, 0xe8, 0x1e, 0xac, 0xcd, 0x58, 0x99, 0x4b, 0xe1, 0x77, 0xb7, 0x1a, 0xa3, 0xee, 0xce, 0xe5,
-	0x9a, 0xed, 0x7a, 0xac, 0x8c, 0x22, 0x6b, 0x8b, 0xbe, 0x83, 0x56, 0x4a, 0xe6, 0x54, 0x75, 0xbd,
-	0xa7, 0xdd, 0x7c, 0x5e, 0xd3, 0xcd, 0x3d, 0x6d, 0x15, 0x39, 0x6b, 0x34, 0x81, 0xb3, 0x39, 0x91,
-	0xaf, 0x19, 0x9f, 0xc6, 0x54, 0xb0, 0x0c, 0x4b, 0xca, 0x72, 0xbf, 0xaf, 0x07, 0xf9, 0x65, 0x4d,
-	0x97, 0x4f, 0x8c, 0xfd, 0x03, 0x67, 0xbe, 0x5f, 0x90, 0x24, 0x1a, 0xe4, 0xc7, 0x50, 0x14, 0x40,
-	0x3f, 0x67, 0x71, 0x41, 0xe7, 0x4c, 0xc6, 0x9c, 0x31, 0xe9, 0xaf, 0xeb, 0xae, 0x76, 0x73, 0xb6,
-	0xa7, 0xb0, 0x88, 0x31, 0x89, 0x46, 0x30, 0x48, 0xc9, 0x4b, 0x5c, 0x66, 0x32, 0x2e, 0x68, 0x1a,
-	0xcf, 0x58, 0x4a, 0xfc, 0x0d, 0x3d, 0x9e, 0x75, 0x8b, 0xef, 0xd1, 0xf4, 0x31, 0x4b, 0xc9, 0x22,
-	0x93, 0x16, 0x89, 0x61, 0x0e, 0x96, 0x98, 0x0f, 0x8a, 0x44, 0x33, 0x3f, 0x86, 0x7e, 0x52, 0x94,
-	0x82, 0x48, 0x37, 0x9f, 0xb3, 0x9a, 0xd6, 0x33, 0xa0, 0x9d, 0xca, 0x87, 0x00, 0x38, 0xcb, 0xd8,
-	0xeb, 0x38, 0xc1, 0x85, 0xf0, 0x91, 0x3e, 0x3c, 0x1d, 0x8d, 0xec, 0xe2, 0x42, 0xa0, 0x00, 0x7a,
-	0x09, 0x2e, 0xf0, 0x0b, 0x9a, 0x51, 0x49, 0x89, 0xf0, 0xdf, 0xd3, 0x84, 0x25, 0x0c, 0x5d, 0x06,
-	0x64, 0x02, 0xc4, 0xf3, 0x9d, 0x98, 0xcd, 0x09, 0xe7, 0x34, 0x25, 0xfe, 0x39, 0x1d, 0x6c, 0x60,
-	0xbe, 0x3c, 0xdb, 0xf9, 0xde, 0xe2, 0xe8, 0xf0, 0x88, 0x7d, 0xf5, 0x88, 0x7d, 0x5e, 0xcf, 0xf2,
-	0x61, 0x58, 0xef, 0xea, 0x87, 0x4b, 0x37, 0x36, 0x34, 0xa5, 0x3c, 0xbb, 0xea, 0x62, 0xdc, 0xcf,
-	0x25, 0x3f, 0xac, 0x42, 0x57, 0xb0, 0x1a, 0x04, 0x63, 0xb3, 0x58, 0x24, 0x8c, 0x93, 0x18, 0xa7,
-	0xaf, 0xfc, 0xcd, 0x2d, 0x6f, 0xd4, 0x8c, 0xba, 0x8c, 0xcd, 0xf6, 0x15, 0xf6, 0x4d, 0xfa, 0x6a,
-	0xb8, 0x0b, 0xe7, 0xdf, 0xea, 0x4e, 0x5d, 0xaf, 0x29, 0x39, 0x74, 0xb2, 0x30, 0x25, 0x87, 0xe8,
-	0x1c, 0x34, 0xe7, 0x38, 0x2b, 0x89, 0xbf, 0xa2, 0x31, 0xb3, 0xf9, 0x62, 0xe5, 0xb6, 0x17, 0xfc,
-	0x0c, 0xeb, 0x2e, 0x43, 0x51, 0xb0, 0x5c, 0x10, 0xf4, 0x04, 0x5a, 0xf6, 0xb2, 0x68, 0x0f, 0xdd,
-	0x9d, 0xeb, 0x75, 0x4b, 0xb5, 0x97, 0x68, 0x5f, 0x62, 0x49, 0x22, 0xe7, 0x24, 0xe8, 0x43, 0xf7,
-	0x39, 0xa6, 0xd2, 0x76, 0x20, 0xf8, 0x09, 0x7a, 0x66, 0xfb, 0x3f, 0x85, 0x7b, 0x04, 0x1b, 0xfb,
-	0x93, 0x52, 0xa6, 0xec, 0x75, 0xee, 0x64, 0x72, 0x13, 0xd6, 0x04, 0x1d, 0xe7, 0x38, 0xb3, 0x2d,
-	0xb1, 0x3b, 0xf4, 0x11, 0xf4, 0xc6, 0x1c, 0x27, 0x24, 0x2e, 0x08, 0xa7, 0x2c, 0xd5, 0xcd, 0x69,
-	0x44, 0x5d, 0x8d, 0xed, 0x69, 0x28, 0x40, 0x30, 0x38, 0xf2, 0x66, 0x32, 0x0e, 0x26, 0xb0, 0xf9,
-	0x43, 0x91, 0xaa, 0xa0, 0x95, 0x3a, 0xda, 0x40, 0x4b, 0x4a, 0xeb, 0xfd, 0x67, 0xa5, 0x0d, 0x2e,
-	0xc0, 0xfb, 0x6f, 0x44, 0xb2, 0x49, 0x0c, 0x60, 0xfd, 0x19, 0xe1, 0x82, 0x32, 0x57, 0x65, 0xf0,
-	0x19, 0x6c, 0x54, 0x88, 0xed, 0xad, 0x0f, 0xad, 0xb9, 0x81, 0x6c, 0xe5, 0x6e, 0x1b, 0x7c, 0x0a,
-	0x3d, 0xd5, 0xb7, 0x2a, 0xf3, 0x21, 0xb4, 0x69, 0x2e, 0x09, 0x9f, 0xdb, 0x26, 0x35, 0xa2, 0x6a,
-	0x1f, 0x3c, 0x87, 0xbe, 0xe5, 0x5a, 0xb7, 0xdf, 0x42, 0x53, 0x28, 0xe0, 0x84, 0x25, 0x3e, 0xc5,
-	0x62, 0x6a, 0x1c, 0x19, 0xf3, 0xe0, 0x12, 0xf4, 0xf7, 0xf5, 0x24, 0xde, 0x3e, 0xa8, 0xa6, 0x1b,
-	0x94, 0x2a, 0xd6, 0x11, 0x6d, 0xf9, 0x53, 0xe8, 0xde, 0x3f, 0x20, 0x89, 0x33, 0xbc, 0x09, 0xed,
-	0x94, 0xe0, 0x34, 0xa3, 0x39, 0xb1, 0x49, 0x0d, 0x43, 0xf3, 0xe4, 0x86, 0xee, 0xc9, 0x0d, 0x9f,
-	0xba, 0x27, 0x37, 0xaa, 0xb8, 0xee, 0x01, 0x5d, 0x79, 0xf3, 0x01, 0x6d, 0x1c, 0x3d, 0xa0, 0xc1,
-	0x2e, 0xf4, 0x4c, 0x30, 0x5b, 0xff, 0x26, 0xac, 0xb1, 0x52, 0x16, 0xa5, 0xd4, 0xb1, 0x7a, 0x91,
-	0xdd, 0xa1, 0x0f, 0xa0, 0x43, 0x0e, 0xa8, 0x8c, 0x13, 0x25, 0x74, 0x2b, 0xba, 0x82, 0xb6, 0x02,
-	0x76, 0x59, 0x4a, 0x82, 0xbf, 0x3c, 0xe8, 0x2d, 0x9e, 0x58, 0x15, 0xbb, 0xa0, 0xa9, 0xad, 0x54,
-	0x2d, 0xdf, 0x69, 0xbf, 0xd0, 0x9b, 0xc6, 0x62, 0x6f, 0x50, 0x08, 0xab, 0xea, 0xcf, 0x84, 0x7e,
-	0x86, 0xdf, 0x5d, 0xb6, 0xe6, 0x29, 0x15, 0x55, 0xca, 0x32, 0xa5, 0x59, 0x46, 0x52, 0xfd, 0x36,
-	0xb7, 0xa3, 0x0e, 0x63, 0xb3, 0x87, 0x1a, 0xd8, 0xf9, 0xa3, 0x03, 0xed, 0xfb, 0xf6, 0x9e, 0xa1,
-	0x43, 0x58, 0x33, 0xe2, 0x80, 0x6e, 0x9c, 0x4a, 0xee, 0x86, 0x37, 0x4f, 0x6a, 0x66, 0xc7, 0x7b,
-	0x06, 0x09, 0x58, 0x55, 0x32, 0x81, 0xae, 0xd5, 0xf5, 0xb0, 0xa0, 0x31, 0xc3, 0xeb, 0x27, 0x33,
-	0xaa, 0x82, 0xfe, 0x06, 0x6d, 0x77, 0xdb, 0xd1, 0xad, 0xba, 0x3e, 0x8e, 0xa9, 0xcd, 0xf0, 0xf6,
-	0xc9, 0x0d, 0xab, 0x04, 0x7e, 0xf7, 0x60, 0xe3, 0xd8, 0x8d, 0x47, 0x5f, 0xd5, 0xf5, 0xf7, 0x76,
-	0x51, 0x1a, 0xde, 0x39, 0xb5, 0x7d, 0x95, 0xd6, 0xaf, 0xd0, 0xb2, 0xd2, 0x82, 0x6a, 0x4f, 0x74,
-	0x59, 0x9d, 0x86, 0xb7, 0x4e, 0x6c, 0x57, 0x45, 0x3f, 0x80, 0xa6, 0x96, 0x0d, 0x54, 0x7b, 0xac,
-	0x8b, 0xd2, 0x36, 0xbc, 0x71, 0x42, 0x2b, 0x17, 0xf7, 0x8a, 0xa7, 0xce, 0xbf, 0xd1, 0x9d, 0xfa,
-	0xe7, 0x7f, 0x49, 0xd0, 0xea, 0x9f, 0xff, 0x63, 0xf2, 0xa6, 0xcf, 0xbf, 0xba, 0x86, 0xf5, 0xcf,
-	0xff, 0x82, 0x1c, 0xd6, 0x3f, 0xff, 0x8b, 0xb2, 0x16, 0x9c, 0x41, 0x7f, 0x7a, 0xd0, 0x57, 0xd0,
-	0xbe, 0xe4, 0x04, 0xcf, 0x68, 0x3e, 0x46, 0x77, 0x6a, 0x6a, 0xbb, 0xb2, 0x32, 0xfa, 0x6e, 0x2d,
-	0x5d, 0x2a, 0x5f, 0x9f, 0xde, 0x81, 0x4b, 0x6b, 0xe4, 0x5d, 0xf1, 0xee, 0xb6, 0x7e, 0x6c, 0x1a,
-	0x49, 0x5b, 0xd3, 0x3f, 0xd7, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0xd2, 0x85, 0x8d, 0xe6, 0xa9,
-	0x0d, 0x00, 0x00,
+	// 1309 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x6d, 0x6f, 0x13, 0xc7,
+	0x16, 0xbe, 0x1b, 0xc7, 0xb1, 0x7d, 0x6c, 0x27, 0x66, 0x2e, 0x84, 0xc1, 0x5c, 0x84, 0xef, 0x5e,
+	0x5d, 0xb0, 0x5a, 0xea, 0x40, 0x08, 0x2f, 0x6a, 0xa5, 0xd2, 0x92, 0xd0, 0x0a, 0xf1, 0xd2, 0x68,
+	0x4d, 0x41, 0xea, 0x87, 0x6e, 0x87, 0xdd, 0x89, 0x3d, 0x78, 0xbd, 0xb3, 0x9d, 0x99, 0x35, 0x89,
+	0x54, 0xa9, 0x7f, 0xa2, 0x95, 0xfa, 0x03, 0xfa, 0x87, 0xfa, 0x8f, 0xaa, 0x79, 0xd9, 0x8d, 0x0d,
+	0xb4, 0xb5, 0xa9, 0xfa, 0xc9, 0x3b, 0x8f, 0x9f, 0xf3, 0x32, 0xe7, 0xcc, 0x79, 0x66, 0xe0, 0x5a,
+	0x2c, 0xd8, 0x8c, 0x0a, 0xb9, 0x23, 0xc7, 0x44, 0xd0, 0x78, 0x87, 0x1e, 0xd3, 0x28, 0x57, 0x5c,
+	0xec, 0x64, 0x82, 0x2b, 0x5e, 0x2e, 0x07, 0x66, 0x89, 0xae, 0x8c, 0x89, 0x1c, 0xb3, 0x88, 0x8b,
+	0x6c, 0x90, 0xf2, 0x29, 0x89, 0x07, 0x59, 0x92, 0x8f, 0x58, 0x2a, 0x07, 0x8b, 0xbc, 0xee, 0xe5,
+	0x11, 0xe7, 0xa3, 0x84, 0x5a, 0x27, 0x2f, 0xf3, 0xa3, 0x1d, 0xc5, 0xa6, 0x54, 0x2a, 0x32, 0xcd,
+	0x1c, 0xc1, 0x77, 0x86, 0x3b, 0x45, 0x78, 0x1b, 0xce, 0xae, 0x2c, 0xc7, 0xff, 0x0d, 0xa0, 0xfd,
+	0x98, 0xe4, 0x69, 0x34, 0x0e, 0xe8, 0xf7, 0x39, 0x95, 0x0a, 0x75, 0xa0, 0x12, 0x4d, 0x63, 0xec,
+	0xf5, 0xbc, 0x7e, 0x23, 0xd0, 0x9f, 0x08, 0xc1, 0x3a, 0x11, 0x23, 0x89, 0xd7, 0x7a, 0x95, 0x7e,
+	0x23, 0x30, 0xdf, 0xe8, 0x29, 0x34, 0x04, 0x95, 0x3c, 0x17, 0x11, 0x95, 0xb8, 0xd2, 0xf3, 0xfa,
+	0xcd, 0xdd, 0xeb, 0x83, 0x3f, 0x4a, 0xdc, 0xc5, 0xb7, 0x21, 0x07, 0x41, 0x61, 0x17, 0x9c, 0xba,
+	0x40, 0x97, 0xa1, 0x29, 0x55, 0xcc, 0x73, 0x15, 0x66, 0x44, 0x8d, 0xf1, 0xba, 0x89, 0x0e, 0x16,
+	0x3a, 0x24, 0x6a, 0xec, 0x08, 0x54, 0x08, 0x4b, 0xa8, 0x96, 0x04, 0x2a, 0x84, 0x21, 0x74, 0xa0,
+	0x42, 0xd3, 0x19, 0xde, 0x30, 0x49, 0xea, 0x4f, 0x9d, 0x77, 0x2e, 0xa9, 0xc0, 0x35, 0xc3, 0x35,
+	0xdf, 0xe8, 0x02, 0xd4, 0x15, 0x91, 0x93, 0x30, 0x66, 0x02, 0xd7, 0x0d, 0x5e, 0xd3, 0xeb, 0x03,
+	0x26, 0xd0, 0x55, 0xd8, 0x2a, 0xf2, 0x09, 0x13, 0x36, 0x65, 0x4a, 0xe2, 0x46, 0xcf, 0xeb, 0xd7,
+	0x83, 0xcd, 0x02, 0x7e, 0x6c, 0x50, 0xb4, 0x07, 0x67, 0x5f, 0x12, 0xc9, 0xa2, 0x30, 0x13, 0x3c,
+	0xa2, 0x52, 0x86, 0xd1, 0x48, 0xf0, 0x3c, 0xc3, 0xa0, 0xd9, 0xf7, 0xd7, 0xb0, 0x17, 0x20, 0xf3,
+	0xff, 0xa1, 0xfd, 0x7b, 0xdf, 0xfc, 0x8b, 0x0e, 0x60, 0x63, 0xca, 0xf3, 0x54, 0x49, 0xdc, 0xec,
+	0x55, 0xfa, 0xcd, 0xdd, 0x6b, 0x4b, 0x96, 0xeb, 0x89, 0x36, 0x0a, 0x9c, 0x2d, 0xfa, 0x12, 0x6a,
+	0x31, 0x9d, 0x31, 0x5d, 0xf5, 0x96, 0x71, 0xf3, 0xd1, 0x92, 0x6e, 0x0e, 0x8c, 0x55, 0x50, 0x58,
+	0xa3, 0x31, 0x9c, 0x49, 0xa9, 0x7a, 0xcd, 0xc5, 0x24, 0x64, 0x92, 0x27, 0x44, 0x31, 0x9e, 0xe2,
+	0xb6, 0x69, 0xe4, 0x27, 0x4b, 0xba, 0x7c, 0x6a, 0xed, 0x1f, 0x16, 0xe6, 0xc3, 0x8c, 0x46, 0x41,
+	0x27, 0x7d, 0x03, 0x45, 0x3e, 0xb4, 0x53, 0x1e, 0x66, 0x6c, 0xc6, 0x55, 0x28, 0x38, 0x57, 0x78,
+	0xd3, 0x54, 0xb5, 0x99, 0xf2, 0x43, 0x8d, 0x05, 0x9c, 0x2b, 0xd4, 0x87, 0x4e, 0x4c, 0x8f, 0x48,
+	0x9e, 0xa8, 0x30, 0x63, 0x71, 0x38, 0xe5, 0x31, 0xc5, 0x5b, 0xa6, 0x3d, 0x9b, 0x0e, 0x3f, 0x64,
+	0xf1, 0x13, 0x1e, 0xd3, 0x79, 0x26, 0xcb, 0x22, 0xcb, 0xec, 0x2c, 0x30, 0x1f, 0x66, 0x91, 0x61,
+	0xfe, 0x0f, 0xda, 0x51, 0x96, 0x4b, 0xaa, 0x8a, 0xfe, 0x9c, 0x31, 0xb4, 0x96, 0x05, 0x5d, 0x57,
+	0x2e, 0x01, 0x90, 0x24, 0xe1, 0xaf, 0xc3, 0x88, 0x64, 0x12, 0x23, 0x73, 0x78, 0x1a, 0x06, 0xd9,
+	0x27, 0x99, 0x44, 0x3e, 0xb4, 0x22, 0x92, 0x91, 0x97, 0x2c, 0x61, 0x8a, 0x51, 0x89, 0xff, 0x6d,
+	0x08, 0x0b, 0x18, 0xba, 0x06, 0xc8, 0x06, 0x08, 0x67, 0xbb, 0x21, 0x9f, 0x51, 0x21, 0x58, 0x4c,
+	0xf1, 0x59, 0x13, 0xac, 0x63, 0xff, 0x79, 0xbe, 0xfb, 0x95, 0xc3, 0xd1, 0xc9, 0x29, 0xfb, 0xc6,
+	0x29, 0xfb, 0x9c, 0xe9, 0xe5, 0xa3, 0xc1, 0x72, 0xa3, 0x3f, 0x58, 0x98, 0xd8, 0x81, 0xdd, 0xca,
+	0xf3, 0x1b, 0x45, 0x8c, 0x07, 0xa9, 0x12, 0x27, 0x65, 0xe8, 0x12, 0xd6, 0x8d, 0xe0, 0x7c, 0x1a,
+	0xca, 0x88, 0x0b, 0x1a, 0x92, 0xf8, 0x15, 0xde, 0xee, 0x79, 0xfd, 0x6a, 0xd0, 0xe4, 0x7c, 0x3a,
+	0xd4, 0xd8, 0xe7, 0xf1, 0x2b, 0x3d, 0x04, 0x92, 0x46, 0x11, 0x9f, 0x66, 0xfa, 0x74, 0x1f, 0xb1,
+	0x84, 0xe2, 0xf3, 0xb6, 0xba, 0x0e, 0x3e, 0xb4, 0x28, 0xfa, 0x3f, 0x6c, 0x26, 0x24, 0x8d, 0x13,
+	0x1e, 0x4d, 0xcc, 0x44, 0x4a, 0x8c, 0x4d, 0x6d, 0xda, 0x05, 0xaa, 0x87, 0xd2, 0xcc, 0xb5, 0x9e,
+	0xbb, 0x54, 0xda, 0x4e, 0x5d, 0xb0, 0x63, 0x6b, 0x21, 0xd3, 0xa5, 0x2b, 0xb0, 0xe5, 0x08, 0x2c,
+	0x0e, 0xa5, 0x22, 0x42, 0xe1, 0x6e, 0xcf, 0xeb, 0xb7, 0x83, 0xb6, 0x85, 0x1f, 0xc6, 0x43, 0x0d,
+	0x2e, 0xf2, 0x22, 0x3d, 0x0c, 0xf8, 0xe2, 0x22, 0x6f, 0x5f, 0x83, 0xe8, 0xbf, 0xd0, 0x62, 0x53,
+	0x32, 0xa2, 0xe6, 0xa8, 0x1d, 0x49, 0xfc, 0x1f, 0x13, 0xb1, 0x69, 0xb0, 0xc0, 0x40, 0x5a, 0x03,
+	0xcc, 0xb9, 0xd7, 0x1a, 0x70, 0xc9, 0x6a, 0x80, 0x5e, 0x1f, 0x30, 0xd1, 0xdd, 0x87, 0x73, 0xef,
+	0xac, 0xa6, 0x56, 0x97, 0x09, 0x3d, 0x29, 0x54, 0x71, 0x42, 0x4f, 0xd0, 0x59, 0xa8, 0xce, 0x48,
+	0x92, 0x53, 0xbc, 0x66, 0x30, 0xbb, 0xf8, 0x78, 0xed, 0xae, 0xe7, 0x7f, 0x07, 0x9b, 0x45, 0x83,
+	0x64, 0xc6, 0x53, 0x49, 0xd1, 0x53, 0xa8, 0x39, 0xad, 0x30, 0x1e, 0x9a, 0xbb, 0x7b, 0xcb, 0x76,
+	0xda, 0x69, 0xc8, 0x50, 0x11, 0x45, 0x83, 0xc2, 0x89, 0xdf, 0x86, 0xe6, 0x0b, 0xc2, 0x94, 0x3b,
+	0x00, 0xfe, 0xb7, 0xd0, 0xb2, 0xcb, 0x7f, 0x28, 0xdc, 0x63, 0xd8, 0x1a, 0x8e, 0x73, 0x15, 0xf3,
+	0xd7, 0x69, 0x71, 0x4b, 0x6c, 0xc3, 0x86, 0x64, 0xa3, 0x94, 0x24, 0xae, 0x24, 0x6e, 0xa5, 0xcb,
+	0x3f, 0x12, 0x24, 0xa2, 0x61, 0x46, 0x05, 0xe3, 0xb1, 0x29, 0x4e, 0x25, 0x68, 0x1a, 0xec, 0xd0,
+	0x40, 0x3e, 0x82, 0xce, 0xa9, 0x37, 0x9b, 0xb1, 0x3f, 0x86, 0xed, 0xaf, 0xb3, 0x58, 0x07, 0x2d,
+	0x2f, 0x07, 0x17, 0x68, 0xe1, 0xa2, 0xf1, 0xfe, 0xf6, 0x45, 0xe3, 0x5f, 0x80, 0xf3, 0x6f, 0x45,
+	0x72, 0x49, 0x74, 0x60, 0xf3, 0x39, 0x15, 0x92, 0xf1, 0x62, 0x97, 0xfe, 0x87, 0xb0, 0x55, 0x22,
+	0xae, 0xb6, 0x18, 0x6a, 0x33, 0x0b, 0xb9, 0x9d, 0x17, 0x4b, 0xff, 0x03, 0x68, 0xe9, 0xba, 0x95,
+	0x99, 0x77, 0xa1, 0xce, 0x52, 0x45, 0xc5, 0xcc, 0x15, 0xa9, 0x12, 0x94, 0x6b, 0xff, 0x05, 0xb4,
+	0x1d, 0xd7, 0xb9, 0xfd, 0x02, 0xaa, 0x52, 0x03, 0x2b, 0x6e, 0xf1, 0x19, 0x91, 0x13, 0xeb, 0xc8,
+	0x9a, 0xfb, 0x57, 0xa1, 0x3d, 0x34, 0x9d, 0x78, 0x77, 0xa3, 0xaa, 0x45, 0xa3, 0xf4, 0x66, 0x0b,
+	0xa2, 0xdb, 0xfe, 0x04, 0x9a, 0x0f, 0x8e, 0x69, 0x54, 0x18, 0xde, 0x86, 0x7a, 0x4c, 0x49, 0x9c,
+	0xb0, 0x94, 0xba, 0xa4, 0xba, 0x03, 0xfb, 0xe2, 0x18, 0x14, 0x2f, 0x8e, 0xc1, 0xb3, 0xe2, 0xc5,
+	0x11, 0x94, 0xdc, 0xe2, 0xfd, 0xb0, 0xf6, 0xf6, 0xfb, 0xa1, 0x72, 0xfa, 0x7e, 0xf0, 0xf7, 0xa1,
+	0x65, 0x83, 0xb9, 0xfd, 0x6f, 0xc3, 0x06, 0xcf, 0x55, 0x96, 0x2b, 0x13, 0xab, 0x15, 0xb8, 0x15,
+	0xba, 0x08, 0x0d, 0x7a, 0xcc, 0x54, 0x18, 0x69, 0xf5, 0x58, 0x33, 0x3b, 0xa8, 0x6b, 0x60, 0x9f,
+	0xc7, 0xd4, 0xff, 0xd5, 0x83, 0xd6, 0xfc, 0x89, 0xd5, 0xb1, 0x33, 0x16, 0xbb, 0x9d, 0xea, 0xcf,
+	0x3f, 0xb5, 0x9f, 0xab, 0x4d, 0x65, 0xbe, 0x36, 0x68, 0x00, 0xeb, 0xfa, 0x2d, 0x85, 0xd7, 0xff,
+	0x72, 0xdb, 0x86, 0xa7, 0x2f, 0x11, 0x2d, 0xac, 0x13, 0x96, 0x24, 0x34, 0x36, 0x4f, 0x93, 0x7a,
+	0xd0, 0xe0, 0x7c, 0xfa, 0xc8, 0x00, 0xbb, 0x3f, 0x37, 0xa0, 0xfe, 0xc0, 0xcd, 0x19, 0x3a, 0x81,
+	0x0d, 0x2b, 0x0e, 0xe8, 0xd6, 0x7b, 0xa9, 0x7d, 0xf7, 0xf6, 0xaa, 0x66, 0xae, 0xbd, 0xff, 0x42,
+	0x12, 0xd6, 0xb5, 0x4c, 0xa0, 0x9b, 0xcb, 0x7a, 0x98, 0xd3, 0x98, 0xee, 0xde, 0x6a, 0x46, 0x65,
+	0xd0, 0x1f, 0xa1, 0x5e, 0x4c, 0x3b, 0xba, 0xb3, 0xac, 0x8f, 0x37, 0xd4, 0xa6, 0x7b, 0x77, 0x75,
+	0xc3, 0x32, 0x81, 0x9f, 0x3c, 0xd8, 0x7a, 0x63, 0xe2, 0xd1, 0xa7, 0xcb, 0xfa, 0x7b, 0xb7, 0x28,
+	0x75, 0xef, 0xbd, 0xb7, 0x7d, 0x99, 0xd6, 0x0f, 0x50, 0x73, 0xd2, 0x82, 0x96, 0xee, 0xe8, 0xa2,
+	0x3a, 0x75, 0xef, 0xac, 0x6c, 0x57, 0x46, 0x3f, 0x86, 0xaa, 0x91, 0x0d, 0xb4, 0x74, 0x5b, 0xe7,
+	0xa5, 0xad, 0x7b, 0x6b, 0x45, 0xab, 0x22, 0xee, 0x75, 0x4f, 0x9f, 0x7f, 0xab, 0x3b, 0xcb, 0x9f,
+	0xff, 0x05, 0x41, 0xeb, 0xde, 0x5e, 0xd5, 0x6c, 0xfe, 0xfc, 0xeb, 0x31, 0x5c, 0xfe, 0xfc, 0xcf,
+	0xc9, 0x61, 0x77, 0x6f, 0x35, 0xa3, 0x32, 0xe8, 0x2f, 0x1e, 0xb4, 0x35, 0x34, 0x54, 0x82, 0x92,
+	0x29, 0x4b, 0x47, 0xe8, 0xde, 0x92, 0xda, 0xae, 0xad, 0xac, 0xbe, 0x3b, 0xcb, 0x22, 0x95, 0xcf,
+	0xde, 0xdf, 0x41, 0x91, 0x56, 0xdf, 0xbb, 0xee, 0xdd, 0xaf, 0x7d, 0x53, 0xb5, 0x92, 0xb6, 0x61,
+	0x7e, 0x6e, 0xfe, 0x3e, 0x00, 0x54, 0xae, 0x61, 0xe9, 0xa8, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
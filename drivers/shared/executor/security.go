@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/nomad/helper/escapingfs"
+	"github.com/shoenig/go-landlock"
+)
+
+const (
+	// SeccompProfileDefault is the seccomp profile denying the system calls
+	// that tasks have no business making, such as loading kernel modules.
+	SeccompProfileDefault = "default"
+
+	// SeccompProfileUnconfined disables seccomp filtering.
+	SeccompProfileUnconfined = "unconfined"
+
+	// SeccompProfileCustom is the entry of the seccomp profile allowlist
+	// enabling profiles loaded from a JSON file in the task directory.
+	SeccompProfileCustom = "custom"
+
+	// securityOptSeccomp is the security_opt key setting the seccomp profile
+	securityOptSeccomp = "seccomp"
+
+	// securityOptLandlock is the security_opt key adding a Landlock path
+	securityOptLandlock = "landlock"
+)
+
+// SecurityOpts are the security options of a task, as set with the
+// security_opt task config of the exec and java drivers.
+type SecurityOpts struct {
+	// Seccomp is the seccomp profile of the task: "default", "unconfined",
+	// or the path of a JSON profile relative to the task directory. Empty
+	// means the driver's default profile.
+	Seccomp string
+
+	// Landlock are the paths the task is restricted to, in the
+	// "type:mode:path" format. Landlock is not applied if empty.
+	Landlock []string
+}
+
+// ParseSecurityOpts parses security_opt entries in the "key=value" format.
+func ParseSecurityOpts(opts []string) (*SecurityOpts, error) {
+	s := new(SecurityOpts)
+	for _, opt := range opts {
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("security_opt %q must be in the key=value format", opt)
+		}
+		switch key {
+		case securityOptSeccomp:
+			if s.Seccomp != "" {
+				return nil, fmt.Errorf("security_opt %q set more than once", key)
+			}
+			s.Seccomp = value
+		case securityOptLandlock:
+			if _, err := landlock.ParsePath(value); err != nil {
+				return nil, fmt.Errorf("security_opt %q has an invalid path %q: %v", key, value, err)
+			}
+			s.Landlock = append(s.Landlock, value)
+		default:
+			return nil, fmt.Errorf("security_opt %q is not supported", key)
+		}
+	}
+	return s, nil
+}
+
+// ValidateSeccompConfig validates the seccomp plugin config of the exec and
+// java drivers. An empty default profile means unconfined.
+func ValidateSeccompConfig(defaultProfile string, allowed []string) error {
+	switch defaultProfile {
+	case "", SeccompProfileDefault, SeccompProfileUnconfined:
+	default:
+		return fmt.Errorf("default_seccomp_profile must be %q or %q, got %q",
+			SeccompProfileDefault, SeccompProfileUnconfined, defaultProfile)
+	}
+	if defaultProfile == SeccompProfileDefault && !SeccompSupported() {
+		return fmt.Errorf("default_seccomp_profile %q requires seccomp support, which this build of Nomad doesn't have", defaultProfile)
+	}
+
+	for _, profile := range allowed {
+		switch profile {
+		case SeccompProfileDefault, SeccompProfileUnconfined, SeccompProfileCustom:
+		default:
+			return fmt.Errorf("allow_seccomp_profiles must only contain %q, %q or %q, got %q",
+				SeccompProfileDefault, SeccompProfileUnconfined, SeccompProfileCustom, profile)
+		}
+	}
+	return nil
+}
+
+// SeccompProfile returns the seccomp profile of a task for the ExecCommand,
+// which is the task's profile if it's allowed or the driver's default one.
+// Custom profiles are returned as their path on the host.
+func SeccompProfile(defaultProfile string, allowed []string, profile, taskDir string) (string, error) {
+	if profile == "" {
+		return defaultProfile, nil
+	}
+
+	kind := profile
+	if profile != SeccompProfileDefault && profile != SeccompProfileUnconfined {
+		kind = SeccompProfileCustom
+	}
+	if !slices.Contains(allowed, kind) {
+		return "", fmt.Errorf("seccomp profile %q is not allowed by the driver config", kind)
+	}
+	if kind != SeccompProfileUnconfined && !SeccompSupported() {
+		return "", fmt.Errorf("seccomp profile %q requires seccomp support, which this build of Nomad doesn't have", kind)
+	}
+	if kind != SeccompProfileCustom {
+		return profile, nil
+	}
+
+	escapes, err := escapingfs.PathEscapesAllocDir(taskDir, "", profile)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve seccomp profile %q: %v", profile, err)
+	}
+	if escapes || filepath.IsAbs(profile) {
+		return "", fmt.Errorf("seccomp profile %q must be a path in the task directory", profile)
+	}
+	return filepath.Join(taskDir, profile), nil
+}
+
+// ValidateLandlock returns an error if the task sets Landlock paths that the
+// node can't enforce.
+func ValidateLandlock(paths []string) error {
+	if len(paths) > 0 && !landlock.Available() {
+		return fmt.Errorf("landlock security_opt requires Landlock, which isn't available on this node")
+	}
+	return nil
+}
+
+// ValidateUserNSConfig validates the user namespace plugin config of the exec
+// and java drivers. An empty default mode means host.
+func ValidateUserNSConfig(defaultMode string, start, count uint32) error {
+	switch defaultMode {
+	case "", IsolationModePrivate, IsolationModeHost:
+	default:
+		return fmt.Errorf("default_userns_mode must be %q or %q, got %q",
+			IsolationModePrivate, IsolationModeHost, defaultMode)
+	}
+	if defaultMode == IsolationModePrivate && start == 0 {
+		return fmt.Errorf("default_userns_mode %q requires userns_id_start to be set", defaultMode)
+	}
+	if start == 0 {
+		return nil
+	}
+	if count == 0 {
+		return fmt.Errorf("userns_id_count must be greater than 0")
+	}
+	if uint64(start)+uint64(count) > math.MaxUint32 {
+		return fmt.Errorf("userns_id_start and userns_id_count exceed the maximum ID %d", uint32(math.MaxUint32))
+	}
+	return nil
+}
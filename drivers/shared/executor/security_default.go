@@ -0,0 +1,12 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package executor
+
+// SeccompSupported returns whether this build of Nomad can apply seccomp
+// profiles, which is never the case outside of Linux.
+func SeccompSupported() bool {
+	return false
+}
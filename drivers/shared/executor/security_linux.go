@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/seccomp"
+	"github.com/opencontainers/runc/libcontainer/specconv"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/shoenig/go-landlock"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// landlockShimPath is where the Nomad binary is mounted in the task's
+	// chroot to restrict the task with Landlock
+	landlockShimPath = "/.nomad-landlock"
+
+	// landlockShimArg is the subcommand handled by the Landlock shim
+	landlockShimArg = "landlock-shim"
+)
+
+// defaultSeccompDenied are the system calls denied by the default seccomp
+// profile. They administer the host rather than the task, and most of them
+// are also denied by Docker's default profile.
+var defaultSeccompDenied = []string{
+	"acct",
+	"add_key",
+	"bpf",
+	"clock_adjtime",
+	"clock_settime",
+	"create_module",
+	"delete_module",
+	"finit_module",
+	"get_kernel_syms",
+	"init_module",
+	"ioperm",
+	"iopl",
+	"kexec_file_load",
+	"kexec_load",
+	"keyctl",
+	"lookup_dcookie",
+	"mount",
+	"move_mount",
+	"name_to_handle_at",
+	"nfsservctl",
+	"open_by_handle_at",
+	"open_tree",
+	"perf_event_open",
+	"pivot_root",
+	"query_module",
+	"quotactl",
+	"reboot",
+	"request_key",
+	"setns",
+	"settimeofday",
+	"stime",
+	"swapoff",
+	"swapon",
+	"_sysctl",
+	"umount",
+	"umount2",
+	"unshare",
+	"uselib",
+	"userfaultfd",
+	"ustat",
+	"vm86",
+	"vm86old",
+}
+
+// SeccompSupported returns whether this build of Nomad can apply seccomp
+// profiles, which requires building with cgo and the seccomp build tag.
+func SeccompSupported() bool {
+	major, _, _ := seccomp.Version()
+	return major > 0
+}
+
+// seccompConfig returns the libcontainer seccomp config of the profile, or
+// nil if the task is unconfined.
+func seccompConfig(profile string) (*configs.Seccomp, error) {
+	switch profile {
+	case "", SeccompProfileUnconfined:
+		return nil, nil
+	case SeccompProfileDefault:
+		errno := uint(syscall.EPERM)
+		cfg := &configs.Seccomp{DefaultAction: configs.Allow}
+		for _, name := range defaultSeccompDenied {
+			cfg.Syscalls = append(cfg.Syscalls, &configs.Syscall{
+				Name:     name,
+				Action:   configs.Errno,
+				ErrnoRet: &errno,
+			})
+		}
+		return cfg, nil
+	}
+
+	// custom profiles use the OCI format, which is compatible with Docker's
+	b, err := os.ReadFile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seccomp profile: %v", err)
+	}
+	var spec specs.LinuxSeccomp
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse seccomp profile: %v", err)
+	}
+	return specconv.SetupSeccomp(&spec)
+}
+
+// landlockMount returns the mount of the Nomad binary used as the Landlock
+// shim in the task's chroot.
+func landlockMount() (*configs.Mount, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find landlock shim: %v", err)
+	}
+	return &configs.Mount{
+		Source:      exe,
+		Destination: landlockShimPath,
+		Device:      "bind",
+		Flags:       unix.MS_BIND | unix.MS_RDONLY,
+	}, nil
+}
+
+// landlockArgs returns the arguments launching the task's command through the
+// Landlock shim.
+func landlockArgs(paths, args []string) []string {
+	shim := []string{landlockShimPath, landlockShimArg}
+	shim = append(shim, paths...)
+	shim = append(shim, "--")
+	return append(shim, args...)
+}
+
+// init handles the Landlock shim, which runs in the task's chroot after the
+// libcontainer shim is done setting up the container. Landlock can't be
+// applied by the libcontainer shim itself, because a process restricted by
+// Landlock can't change its mounts.
+func init() {
+	if len(os.Args) > 1 && os.Args[1] == landlockShimArg {
+		if err := landlockShim(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to restrict task with landlock: %v\n", err)
+			os.Exit(1)
+		}
+		panic("--this line should have never been executed, congratulations--")
+	}
+}
+
+func landlockShim(args []string) error {
+	sep := slices.Index(args, "--")
+	if sep < 0 || sep == len(args)-1 {
+		return fmt.Errorf("missing command")
+	}
+	cmd := args[sep+1:]
+
+	// tasks can always use their shared libraries and own directories
+	paths := []*landlock.Path{
+		landlock.Shared(),
+		landlock.Stdio(),
+		landlock.File(cmd[0], "rx"),
+	}
+	for _, dir := range []string{"/alloc", "/local", "/tmp"} {
+		if _, err := os.Stat(dir); err == nil {
+			paths = append(paths, landlock.Dir(dir, "rwc"))
+		}
+	}
+	if _, err := os.Stat("/secrets"); err == nil {
+		paths = append(paths, landlock.Dir("/secrets", "r"))
+	}
+
+	for _, arg := range args[:sep] {
+		path, err := landlock.ParsePath(arg)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+	}
+
+	if err := landlock.New(paths...).Lock(landlock.Mandatory); err != nil {
+		return err
+	}
+	return syscall.Exec(cmd[0], cmd, os.Environ())
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/shoenig/test/must"
+)
+
+func TestSecurity_seccompConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	cfg, err := seccompConfig("")
+	must.NoError(t, err)
+	must.Nil(t, cfg)
+
+	cfg, err = seccompConfig(SeccompProfileUnconfined)
+	must.NoError(t, err)
+	must.Nil(t, cfg)
+
+	cfg, err = seccompConfig(SeccompProfileDefault)
+	must.NoError(t, err)
+	must.Eq(t, configs.Allow, cfg.DefaultAction)
+	must.Len(t, len(defaultSeccompDenied), cfg.Syscalls)
+	for _, call := range cfg.Syscalls {
+		must.Eq(t, configs.Errno, call.Action)
+	}
+
+	profile := filepath.Join(t.TempDir(), "profile.json")
+	must.NoError(t, os.WriteFile(profile, []byte(`{
+  "defaultAction": "SCMP_ACT_ERRNO",
+  "syscalls": [
+    {"names": ["read", "write", "exit_group"], "action": "SCMP_ACT_ALLOW"}
+  ]
+}`), 0o644))
+	cfg, err = seccompConfig(profile)
+	must.NoError(t, err)
+	must.Eq(t, configs.Errno, cfg.DefaultAction)
+	must.Len(t, 3, cfg.Syscalls)
+	must.Eq(t, "read", cfg.Syscalls[0].Name)
+	must.Eq(t, configs.Allow, cfg.Syscalls[0].Action)
+
+	_, err = seccompConfig(filepath.Join(t.TempDir(), "missing.json"))
+	must.ErrorContains(t, err, "failed to read seccomp profile")
+}
+
+func TestSecurity_landlockArgs(t *testing.T) {
+	ci.Parallel(t)
+
+	args := landlockArgs([]string{"d:r:/etc"}, []string{"/bin/cat", "/etc/hosts"})
+	must.Eq(t, []string{
+		landlockShimPath, landlockShimArg, "d:r:/etc", "--", "/bin/cat", "/etc/hosts",
+	}, args)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestSecurity_ParseSecurityOpts(t *testing.T) {
+	ci.Parallel(t)
+
+	opts, err := ParseSecurityOpts([]string{
+		"seccomp=local/profile.json",
+		"landlock=d:r:/etc",
+		"landlock=f:rx:/bin/true",
+	})
+	must.NoError(t, err)
+	must.Eq(t, "local/profile.json", opts.Seccomp)
+	must.Eq(t, []string{"d:r:/etc", "f:rx:/bin/true"}, opts.Landlock)
+
+	for _, tc := range []struct {
+		opts []string
+		exp  string
+	}{
+		{opts: []string{"seccomp"}, exp: "must be in the key=value format"},
+		{opts: []string{"seccomp="}, exp: "must be in the key=value format"},
+		{opts: []string{"seccomp=default", "seccomp=unconfined"}, exp: "set more than once"},
+		{opts: []string{"landlock=/etc"}, exp: "invalid path"},
+		{opts: []string{"apparmor=unconfined"}, exp: "is not supported"},
+	} {
+		_, err := ParseSecurityOpts(tc.opts)
+		must.ErrorContains(t, err, tc.exp)
+	}
+}
+
+func TestSecurity_ValidateSeccompConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateSeccompConfig("", nil))
+	must.NoError(t, ValidateSeccompConfig(SeccompProfileUnconfined, []string{"default", "unconfined", "custom"}))
+
+	err := ValidateSeccompConfig("other", nil)
+	must.EqError(t, err, `default_seccomp_profile must be "default" or "unconfined", got "other"`)
+
+	err = ValidateSeccompConfig(SeccompProfileUnconfined, []string{"default", "other"})
+	must.ErrorContains(t, err, `allow_seccomp_profiles must only contain`)
+
+	err = ValidateSeccompConfig(SeccompProfileDefault, nil)
+	if SeccompSupported() {
+		must.NoError(t, err)
+	} else {
+		must.ErrorContains(t, err, "requires seccomp support")
+	}
+}
+
+func TestSecurity_ValidateUserNSConfig(t *testing.T) {
+	ci.Parallel(t)
+
+	must.NoError(t, ValidateUserNSConfig("", 0, 0))
+	must.NoError(t, ValidateUserNSConfig(IsolationModeHost, 100000, 65536))
+	must.NoError(t, ValidateUserNSConfig(IsolationModePrivate, 100000, 65536))
+
+	err := ValidateUserNSConfig("other", 0, 0)
+	must.EqError(t, err, `default_userns_mode must be "private" or "host", got "other"`)
+
+	err = ValidateUserNSConfig(IsolationModePrivate, 0, 65536)
+	must.ErrorContains(t, err, "requires userns_id_start to be set")
+
+	err = ValidateUserNSConfig(IsolationModeHost, 100000, 0)
+	must.ErrorContains(t, err, "userns_id_count must be greater than 0")
+
+	err = ValidateUserNSConfig(IsolationModeHost, 4294900000, 100000)
+	must.ErrorContains(t, err, "exceed the maximum ID")
+}
+
+func TestSecurity_SeccompProfile(t *testing.T) {
+	ci.Parallel(t)
+
+	taskDir := t.TempDir()
+	allowed := []string{SeccompProfileUnconfined, SeccompProfileCustom}
+
+	// tasks without a profile get the driver's default
+	profile, err := SeccompProfile(SeccompProfileUnconfined, allowed, "", taskDir)
+	must.NoError(t, err)
+	must.Eq(t, SeccompProfileUnconfined, profile)
+
+	profile, err = SeccompProfile(SeccompProfileDefault, allowed, SeccompProfileUnconfined, taskDir)
+	must.NoError(t, err)
+	must.Eq(t, SeccompProfileUnconfined, profile)
+
+	_, err = SeccompProfile(SeccompProfileUnconfined, allowed, SeccompProfileDefault, taskDir)
+	must.EqError(t, err, `seccomp profile "default" is not allowed by the driver config`)
+
+	_, err = SeccompProfile(SeccompProfileUnconfined, nil, "local/profile.json", taskDir)
+	must.EqError(t, err, `seccomp profile "custom" is not allowed by the driver config`)
+
+	if !SeccompSupported() {
+		_, err = SeccompProfile(SeccompProfileUnconfined, allowed, "local/profile.json", taskDir)
+		must.ErrorContains(t, err, "requires seccomp support")
+		return
+	}
+
+	must.NoError(t, os.Mkdir(filepath.Join(taskDir, "local"), 0o755))
+	profile, err = SeccompProfile(SeccompProfileUnconfined, allowed, "local/profile.json", taskDir)
+	must.NoError(t, err)
+	must.Eq(t, filepath.Join(taskDir, "local/profile.json"), profile)
+
+	for _, escaping := range []string{"../profile.json", "/etc/profile.json"} {
+		_, err = SeccompProfile(SeccompProfileUnconfined, allowed, escaping, taskDir)
+		must.ErrorContains(t, err, "must be a path in the task directory")
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package ociimage unpacks OCI image layouts into a node-local layer cache,
+// and assembles the cached layers into the root filesystem of tasks.
+package ociimage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// mediaTypeDockerManifest and mediaTypeDockerManifestList are the media
+	// types of the manifests of images saved by Docker
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// layersDir, rootfsDir and tmpDir are the directories of the cache
+	// holding the unpacked layers, the root filesystems of tasks, and image
+	// tarballs while they're read.
+	layersDir = "layers"
+	rootfsDir = "rootfs"
+	tmpDir    = "tmp"
+)
+
+// Image is an OCI image whose layers are unpacked in the cache.
+type Image struct {
+	// Config is the runtime configuration of the image, such as its
+	// environment and entrypoint.
+	Config ocispec.ImageConfig
+
+	// Layers are the directories of the unpacked layers in the cache, from
+	// the base layer to the top one.
+	Layers []string
+}
+
+// Cache is a node-local cache of unpacked image layers, keyed by the digest
+// of the layer. Layers shared by several images are unpacked only once.
+type Cache struct {
+	dir    string
+	logger hclog.Logger
+
+	// lock serializes the unpacking of layers
+	lock sync.Mutex
+}
+
+// NewCache returns a cache storing layers and task root filesystems in dir.
+func NewCache(dir string, logger hclog.Logger) *Cache {
+	return &Cache{
+		dir:    dir,
+		logger: logger.Named("image_cache"),
+	}
+}
+
+// Load reads the OCI image layout at path, which is either a directory or a
+// tarball of one, and unpacks the layers of the image missing from the cache.
+func (c *Cache) Load(path string) (*Image, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	layout := path
+	if !fi.IsDir() {
+		// image tarballs are extracted to a temporary directory, as blobs
+		// can't be read out of order from a tar stream
+		layout = filepath.Join(c.dir, tmpDir, uuid.Generate())
+		defer os.RemoveAll(layout)
+		if err := extractTarball(path, layout); err != nil {
+			return nil, fmt.Errorf("failed to extract image %q: %w", path, err)
+		}
+	}
+
+	manifest, err := readManifest(layout)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ocispec.Image
+	if err := readBlobJSON(layout, manifest.Config, &config); err != nil {
+		return nil, fmt.Errorf("failed to read image config: %w", err)
+	}
+
+	img := &Image{Config: config.Config}
+	for _, layer := range manifest.Layers {
+		dir, err := c.unpackLayer(layout, layer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack layer %s: %w", layer.Digest, err)
+		}
+		img.Layers = append(img.Layers, dir)
+	}
+	return img, nil
+}
+
+// readManifest returns the image manifest of the layout, picking the one
+// matching the platform of the node if the index lists several.
+func readManifest(layout string) (*ocispec.Manifest, error) {
+	var index ocispec.Index
+	buf, err := os.ReadFile(filepath.Join(layout, ocispec.ImageIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image index: %w", err)
+	}
+	if err := json.Unmarshal(buf, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode image index: %w", err)
+	}
+
+	for {
+		desc, err := platformManifest(index.Manifests)
+		if err != nil {
+			return nil, err
+		}
+
+		switch desc.MediaType {
+		case ocispec.MediaTypeImageIndex, mediaTypeDockerManifestList:
+			index = ocispec.Index{}
+			if err := readBlobJSON(layout, desc, &index); err != nil {
+				return nil, fmt.Errorf("failed to read image index: %w", err)
+			}
+		case ocispec.MediaTypeImageManifest, mediaTypeDockerManifest:
+			var manifest ocispec.Manifest
+			if err := readBlobJSON(layout, desc, &manifest); err != nil {
+				return nil, fmt.Errorf("failed to read image manifest: %w", err)
+			}
+			return &manifest, nil
+		default:
+			return nil, fmt.Errorf("unsupported manifest media type %q", desc.MediaType)
+		}
+	}
+}
+
+// platformManifest returns the manifest matching the platform of the node,
+// or the only one if it doesn't set a platform.
+func platformManifest(manifests []ocispec.Descriptor) (ocispec.Descriptor, error) {
+	for _, desc := range manifests {
+		if desc.Platform == nil {
+			if len(manifests) == 1 {
+				return desc, nil
+			}
+			continue
+		}
+		if desc.Platform.OS == runtime.GOOS && desc.Platform.Architecture == runtime.GOARCH {
+			return desc, nil
+		}
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("image has no manifest for %s/%s", runtime.GOOS, runtime.GOARCH)
+}
+
+// openBlob opens the blob of the descriptor in the layout.
+func openBlob(layout string, desc ocispec.Descriptor) (*os.File, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, err
+	}
+	return os.Open(filepath.Join(layout, ocispec.ImageBlobsDir,
+		desc.Digest.Algorithm().String(), desc.Digest.Encoded()))
+}
+
+// readBlobJSON decodes the JSON blob of the descriptor after verifying its
+// digest.
+func readBlobJSON(layout string, desc ocispec.Descriptor, v any) error {
+	f, err := openBlob(layout, desc)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if actual := desc.Digest.Algorithm().FromBytes(buf); actual != desc.Digest {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", desc.Digest, actual)
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// unpackLayer unpacks the layer into the cache unless it's already there,
+// and returns its directory.
+func (c *Cache) unpackLayer(layout string, desc ocispec.Descriptor) (string, error) {
+	if err := desc.Digest.Validate(); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(c.dir, layersDir, desc.Digest.Encoded())
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	f, err := openBlob(layout, desc)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	verifier := desc.Digest.Verifier()
+	blob := io.TeeReader(f, verifier)
+	r := blob
+
+	switch {
+	case strings.HasSuffix(desc.MediaType, "+gzip"), strings.HasSuffix(desc.MediaType, ".tar.gzip"):
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+		r = gz
+	case strings.HasSuffix(desc.MediaType, ".tar"):
+	default:
+		return "", fmt.Errorf("unsupported layer media type %q", desc.MediaType)
+	}
+
+	// unpack to a temporary directory renamed once complete, so that a
+	// failure never leaves a partial layer in the cache
+	tmp := dir + ".tmp"
+	if err := os.RemoveAll(tmp); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(tmp, 0o755); err != nil {
+		return "", err
+	}
+	if err := unpack(tar.NewReader(r), tmp); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+
+	// read the remaining bytes of the blob so the digest covers all of it
+	if _, err := io.Copy(io.Discard, blob); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	if !verifier.Verified() {
+		os.RemoveAll(tmp)
+		return "", fmt.Errorf("digest mismatch for %s", desc.Digest)
+	}
+
+	if err := os.Rename(tmp, dir); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	c.logger.Debug("unpacked image layer", "digest", desc.Digest)
+	return dir, nil
+}
+
+// extractTarball extracts the image layout tarball at path to dir. Only
+// directories and regular files are extracted.
+func extractTarball(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := securejoin.SecureJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeFile writes the content of r to a new file at path.
+func writeFile(path string, r io.Reader, mode os.FileMode) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// rootfsPaths returns the directories of the root filesystem of the task
+// with the given name: the writable upper directory, the work directory of
+// overlayfs, and the merged mount point.
+func (c *Cache) rootfsPaths(name string) (string, string, string) {
+	base := filepath.Join(c.dir, rootfsDir, name)
+	return filepath.Join(base, "upper"), filepath.Join(base, "work"), filepath.Join(base, "merged")
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !linux
+
+package ociimage
+
+import (
+	"archive/tar"
+	"errors"
+)
+
+var errNotSupported = errors.New("image root filesystems are only supported on Linux")
+
+// Mount is not supported on this platform.
+func (c *Cache) Mount(*Image, string) (string, error) {
+	return "", errNotSupported
+}
+
+// Unmount is a no-op on this platform, as Mount is not supported.
+func (c *Cache) Unmount(string) error {
+	return nil
+}
+
+func unpack(*tar.Reader, string) error {
+	return errNotSupported
+}
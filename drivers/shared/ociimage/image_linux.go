@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package ociimage
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// whiteoutPrefix marks the files deleted by a layer, and whiteoutOpaque
+	// the directories whose content in lower layers is hidden.
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+
+	// paxXattrPrefix is the prefix of the PAX records holding xattrs
+	paxXattrPrefix = "SCHILY.xattr."
+)
+
+// Mount assembles the layers of the image into an overlay filesystem, which
+// is the root filesystem of the task with the given name. A previous root
+// filesystem of the task is discarded.
+func (c *Cache) Mount(img *Image, name string) (string, error) {
+	if len(img.Layers) == 0 {
+		return "", fmt.Errorf("image has no layers")
+	}
+	if err := c.Unmount(name); err != nil {
+		return "", err
+	}
+
+	upper, work, merged := c.rootfsPaths(name)
+	for _, dir := range []string{upper, work, merged} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return "", err
+		}
+	}
+
+	// overlayfs lists lower directories from the top layer down
+	lower := make([]string, 0, len(img.Layers))
+	for i := len(img.Layers) - 1; i >= 0; i-- {
+		lower = append(lower, img.Layers[i])
+	}
+	data := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", strings.Join(lower, ":"), upper, work)
+	if err := unix.Mount("overlay", merged, "overlay", 0, data); err != nil {
+		return "", fmt.Errorf("failed to mount image root filesystem: %w", err)
+	}
+	return merged, nil
+}
+
+// Unmount unmounts and removes the root filesystem of the task with the
+// given name. It's a no-op if the task has no root filesystem.
+func (c *Cache) Unmount(name string) error {
+	_, _, merged := c.rootfsPaths(name)
+	if err := unix.Unmount(merged, unix.MNT_DETACH); err != nil &&
+		!errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+		return fmt.Errorf("failed to unmount image root filesystem: %w", err)
+	}
+	return os.RemoveAll(filepath.Dir(merged))
+}
+
+// unpack extracts the layer tar stream to dir, converting OCI whiteouts to
+// the overlayfs format.
+func unpack(tr *tar.Reader, dir string) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := securejoin.SecureJoin(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+
+		base := filepath.Base(target)
+		switch {
+		case base == whiteoutOpaque:
+			if err := unix.Setxattr(filepath.Dir(target), "trusted.overlay.opaque", []byte("y"), 0); err != nil {
+				return fmt.Errorf("failed to mark %q opaque: %w", hdr.Name, err)
+			}
+			continue
+		case strings.HasPrefix(base, whiteoutPrefix):
+			deleted := filepath.Join(filepath.Dir(target), strings.TrimPrefix(base, whiteoutPrefix))
+			if err := unix.Mknod(deleted, unix.S_IFCHR, 0); err != nil {
+				return fmt.Errorf("failed to create whiteout for %q: %w", hdr.Name, err)
+			}
+			continue
+		}
+
+		if err := unpackEntry(tr, hdr, dir, target); err != nil {
+			return fmt.Errorf("failed to unpack %q: %w", hdr.Name, err)
+		}
+	}
+}
+
+// unpackEntry creates the file of the tar header at target, with the owner,
+// mode, xattrs and modification time of the entry.
+func unpackEntry(r io.Reader, hdr *tar.Header, dir, target string) error {
+	mode := uint32(hdr.FileInfo().Mode().Perm())
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		if err := os.Mkdir(target, os.FileMode(mode)); err != nil && !os.IsExist(err) {
+			return err
+		}
+	case tar.TypeReg:
+		if err := writeFile(target, r, os.FileMode(mode)); err != nil {
+			return err
+		}
+	case tar.TypeSymlink:
+		if err := os.Symlink(hdr.Linkname, target); err != nil {
+			return err
+		}
+	case tar.TypeLink:
+		source, err := securejoin.SecureJoin(dir, hdr.Linkname)
+		if err != nil {
+			return err
+		}
+		return os.Link(source, target)
+	case tar.TypeChar:
+		if err := unix.Mknod(target, unix.S_IFCHR|mode, int(unix.Mkdev(uint32(hdr.Devmajor), uint32(hdr.Devminor)))); err != nil {
+			return err
+		}
+	case tar.TypeBlock:
+		if err := unix.Mknod(target, unix.S_IFBLK|mode, int(unix.Mkdev(uint32(hdr.Devmajor), uint32(hdr.Devminor)))); err != nil {
+			return err
+		}
+	case tar.TypeFifo:
+		if err := unix.Mkfifo(target, mode); err != nil {
+			return err
+		}
+	default:
+		// other entries, such as PAX global headers, have no file
+		return nil
+	}
+
+	if err := os.Lchown(target, hdr.Uid, hdr.Gid); err != nil {
+		return err
+	}
+	for key, value := range hdr.PAXRecords {
+		if name, ok := strings.CutPrefix(key, paxXattrPrefix); ok {
+			if err := unix.Lsetxattr(target, name, []byte(value), 0); err != nil {
+				return err
+			}
+		}
+	}
+	if hdr.Typeflag == tar.TypeSymlink {
+		return nil
+	}
+
+	// chown clears the setuid and setgid bits, so the mode is set after
+	if err := os.Chmod(target, hdr.FileInfo().Mode()&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	return os.Chtimes(target, time.Time{}, hdr.ModTime)
+}
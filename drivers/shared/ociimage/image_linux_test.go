@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build linux
+
+package ociimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/shoenig/test/must"
+	"golang.org/x/sys/unix"
+)
+
+// testEntry is a file of a test layer
+type testEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+// writeBlob writes the blob to the layout and returns its descriptor.
+func writeBlob(t *testing.T, layout, mediaType string, data []byte) ocispec.Descriptor {
+	t.Helper()
+	dgst := digest.FromBytes(data)
+	dir := filepath.Join(layout, ocispec.ImageBlobsDir, dgst.Algorithm().String())
+	must.NoError(t, os.MkdirAll(dir, 0o755))
+	must.NoError(t, os.WriteFile(filepath.Join(dir, dgst.Encoded()), data, 0o644))
+	return ocispec.Descriptor{MediaType: mediaType, Digest: dgst, Size: int64(len(data))}
+}
+
+// writeJSONBlob writes the JSON encoding of v to the layout.
+func writeJSONBlob(t *testing.T, layout, mediaType string, v any) ocispec.Descriptor {
+	t.Helper()
+	buf, err := json.Marshal(v)
+	must.NoError(t, err)
+	return writeBlob(t, layout, mediaType, buf)
+}
+
+// testLayer returns a gzipped layer tarball of the entries.
+func testLayer(t *testing.T, entries []testEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0o755,
+			Size:     int64(len(e.body)),
+		}
+		must.NoError(t, tw.WriteHeader(hdr))
+		if e.body != "" {
+			_, err := tw.Write([]byte(e.body))
+			must.NoError(t, err)
+		}
+	}
+	must.NoError(t, tw.Close())
+	must.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// testLayout writes an OCI image layout with the layers to a new directory.
+func testLayout(t *testing.T, layers ...[]byte) string {
+	t.Helper()
+	layout := t.TempDir()
+
+	config := writeJSONBlob(t, layout, ocispec.MediaTypeImageConfig, ocispec.Image{
+		Platform: ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH},
+		Config: ocispec.ImageConfig{
+			Env:        []string{"PATH=/usr/bin:/bin"},
+			Entrypoint: []string{"/bin/app"},
+			Cmd:        []string{"serve"},
+		},
+	})
+
+	manifest := ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    config,
+	}
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers,
+			writeBlob(t, layout, ocispec.MediaTypeImageLayerGzip, layer))
+	}
+	desc := writeJSONBlob(t, layout, ocispec.MediaTypeImageManifest, manifest)
+	desc.Platform = &ocispec.Platform{OS: runtime.GOOS, Architecture: runtime.GOARCH}
+
+	index, err := json.Marshal(ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Manifests: []ocispec.Descriptor{desc},
+	})
+	must.NoError(t, err)
+	must.NoError(t, os.WriteFile(filepath.Join(layout, ocispec.ImageIndexFile), index, 0o644))
+	must.NoError(t, os.WriteFile(filepath.Join(layout, ocispec.ImageLayoutFile),
+		[]byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+	return layout
+}
+
+// tarLayout writes the layout to a tarball and returns its path.
+func tarLayout(t *testing.T, layout string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "image.tar")
+	f, err := os.Create(path)
+	must.NoError(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	must.NoError(t, filepath.Walk(layout, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(layout, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fi, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		buf, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(buf)
+		return err
+	}))
+	must.NoError(t, tw.Close())
+	return path
+}
+
+func TestCache_Load(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	base := testLayer(t, []testEntry{
+		{name: "bin/", typeflag: tar.TypeDir},
+		{name: "bin/app", typeflag: tar.TypeReg, body: "app"},
+		{name: "bin/sh", typeflag: tar.TypeSymlink, linkname: "/bin/app"},
+		{name: "etc/", typeflag: tar.TypeDir},
+		{name: "etc/removed", typeflag: tar.TypeReg, body: "removed"},
+	})
+	top := testLayer(t, []testEntry{
+		{name: "etc/.wh.removed", typeflag: tar.TypeReg},
+		{name: "var/.wh..wh..opq", typeflag: tar.TypeReg},
+	})
+	layout := testLayout(t, base, top)
+	cache := NewCache(t.TempDir(), hclog.NewNullLogger())
+
+	img, err := cache.Load(layout)
+	must.NoError(t, err)
+	must.Eq(t, []string{"/bin/app"}, img.Config.Entrypoint)
+	must.Eq(t, []string{"serve"}, img.Config.Cmd)
+	must.Len(t, 2, img.Layers)
+
+	buf, err := os.ReadFile(filepath.Join(img.Layers[0], "bin", "app"))
+	must.NoError(t, err)
+	must.Eq(t, "app", string(buf))
+
+	link, err := os.Readlink(filepath.Join(img.Layers[0], "bin", "sh"))
+	must.NoError(t, err)
+	must.Eq(t, "/bin/app", link)
+
+	// whiteouts are converted to the overlayfs format
+	fi, err := os.Lstat(filepath.Join(img.Layers[1], "etc", "removed"))
+	must.NoError(t, err)
+	must.Eq(t, os.ModeCharDevice|os.ModeDevice, fi.Mode().Type())
+
+	opaque := make([]byte, 1)
+	_, err = unix.Getxattr(filepath.Join(img.Layers[1], "var"), "trusted.overlay.opaque", opaque)
+	must.NoError(t, err)
+	must.Eq(t, "y", string(opaque))
+
+	// loading the tarball of the image uses the cached layers
+	must.NoError(t, os.WriteFile(filepath.Join(img.Layers[0], "cached"), nil, 0o644))
+	img, err = cache.Load(tarLayout(t, layout))
+	must.NoError(t, err)
+	must.FileExists(t, filepath.Join(img.Layers[0], "cached"))
+}
+
+func TestCache_Load_digestMismatch(t *testing.T) {
+	ci.Parallel(t)
+
+	layer := testLayer(t, []testEntry{{name: "app", typeflag: tar.TypeReg, body: "app"}})
+	layout := testLayout(t, layer)
+
+	// corrupt the layer blob
+	dgst := digest.FromBytes(layer)
+	path := filepath.Join(layout, ocispec.ImageBlobsDir, "sha256", dgst.Encoded())
+	must.NoError(t, os.WriteFile(path, testLayer(t, nil), 0o644))
+
+	cache := NewCache(t.TempDir(), hclog.NewNullLogger())
+	_, err := cache.Load(layout)
+	must.ErrorContains(t, err, "digest mismatch")
+	must.DirNotExists(t, filepath.Join(cache.dir, layersDir, dgst.Encoded()))
+}
+
+func TestCache_Load_escape(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	layer := testLayer(t, []testEntry{
+		{name: "../../escaped", typeflag: tar.TypeReg, body: "escaped"},
+	})
+	layout := testLayout(t, layer)
+	cache := NewCache(t.TempDir(), hclog.NewNullLogger())
+
+	img, err := cache.Load(layout)
+	must.NoError(t, err)
+	must.FileExists(t, filepath.Join(img.Layers[0], "escaped"))
+	must.FileNotExists(t, filepath.Join(cache.dir, "escaped"))
+}
+
+func TestCache_Mount(t *testing.T) {
+	ci.Parallel(t)
+	if unix.Geteuid() != 0 {
+		t.Skip("Must be run as root")
+	}
+
+	base := testLayer(t, []testEntry{
+		{name: "etc/", typeflag: tar.TypeDir},
+		{name: "etc/kept", typeflag: tar.TypeReg, body: "kept"},
+		{name: "etc/removed", typeflag: tar.TypeReg, body: "removed"},
+	})
+	top := testLayer(t, []testEntry{
+		{name: "etc/.wh.removed", typeflag: tar.TypeReg},
+	})
+	cache := NewCache(t.TempDir(), hclog.NewNullLogger())
+	img, err := cache.Load(testLayout(t, base, top))
+	must.NoError(t, err)
+
+	rootfs, err := cache.Mount(img, "task")
+	if err != nil {
+		t.Skipf("overlayfs is not available: %v", err)
+	}
+	t.Cleanup(func() { _ = cache.Unmount("task") })
+
+	must.FileExists(t, filepath.Join(rootfs, "etc", "kept"))
+	must.FileNotExists(t, filepath.Join(rootfs, "etc", "removed"))
+
+	// writes go to the task's upper directory, not the cached layers
+	must.NoError(t, os.WriteFile(filepath.Join(rootfs, "etc", "kept"), []byte("changed"), 0o644))
+	buf, err := os.ReadFile(filepath.Join(img.Layers[0], "etc", "kept"))
+	must.NoError(t, err)
+	must.Eq(t, "kept", string(buf))
+
+	must.NoError(t, cache.Unmount("task"))
+	must.DirNotExists(t, filepath.Join(cache.dir, rootfsDir, "task"))
+}
@@ -435,6 +435,7 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"auto_revert",
 		"auto_promote",
 		"canary",
+		"prefetch",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
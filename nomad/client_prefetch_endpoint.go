@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ClientPrefetch is used to forward RPC requests to the targed Nomad client's
+// Prefetch endpoint.
+type ClientPrefetch struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger log.Logger
+}
+
+func NewClientPrefetchEndpoint(srv *Server, ctx *RPCContext) *ClientPrefetch {
+	return &ClientPrefetch{srv: srv, ctx: ctx, logger: srv.logger.Named("client_prefetch")}
+}
+
+func (c *ClientPrefetch) TaskGroup(args *cstructs.ClientPrefetchRequest, reply *cstructs.ClientPrefetchResponse) error {
+	defer metrics.MeasureSince([]string{"nomad", "client_prefetch", "task_group"}, time.Now())
+
+	// client requests aren't RequestWithIdentity, so we use a placeholder here
+	// to populate the identity data for metrics
+	identityReq := &structs.GenericRequest{}
+	aclObj, err := c.srv.AuthenticateServerOnly(c.ctx, identityReq)
+	c.srv.MeasureRPCRate("client_prefetch", structs.RateMetricWrite, identityReq)
+
+	if err != nil || !aclObj.AllowServerOp() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Make sure Node is valid and new enough to support RPC
+	snap, err := c.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	_, err = getNodeForRpc(snap, args.NodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := c.srv.getNodeConn(args.NodeID)
+	if !ok {
+		return findNodeConnAndForward(c.srv, args.NodeID, "ClientPrefetch.TaskGroup", args, reply)
+	}
+
+	// Make the RPC
+	if err := NodeRpc(state.Session, "Prefetch.TaskGroup", args, reply); err != nil {
+		return fmt.Errorf("Prefetch.TaskGroup error: %w", err)
+	}
+	return nil
+}
@@ -57,3 +57,18 @@ func (d *deploymentWatcherRaftShim) UpdateAllocDesiredTransition(req *structs.Al
 	fsmErrIntf, index, raftErr := d.apply(structs.AllocUpdateDesiredTransitionRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
+
+// deploymentWatcherPrefetchShim is the shim that lets the deployment watcher
+// prefetch the task groups of new deployments on candidate nodes.
+type deploymentWatcherPrefetchShim struct {
+	srv *Server
+}
+
+func (d *deploymentWatcherPrefetchShim) PrefetchTaskGroups(deployment *structs.Deployment, job *structs.Job, groups []string) {
+	go func() {
+		if _, err := d.srv.prefetchJob(job, groups, deployment.ID); err != nil {
+			d.srv.logger.Error("failed to prefetch deployment",
+				"deployment_id", deployment.ID, "error", err)
+		}
+	}()
+}
@@ -6,6 +6,7 @@ package deploymentwatcher
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -53,6 +54,10 @@ type deploymentTriggers interface {
 	// upsertDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
+
+	// prefetchTaskGroups is used to fetch the images and artifacts of task
+	// groups on the nodes where they could be placed
+	prefetchTaskGroups(d *structs.Deployment, job *structs.Job, groups []string)
 }
 
 // deploymentWatcher is used to watch a single deployment and trigger the
@@ -136,6 +141,29 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	return w
 }
 
+// prefetch starts prefetching the task groups of the deployment that set
+// update.prefetch, unless a previous leader already started it.
+func (w *deploymentWatcher) prefetch() {
+	d := w.getDeployment()
+	if !d.Active() {
+		return
+	}
+
+	var groups []string
+	for name, dstate := range d.TaskGroups {
+		if dstate.Prefetch && dstate.PrefetchNodes == 0 {
+			groups = append(groups, name)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	sort.Strings(groups)
+
+	w.logger.Debug("prefetching task groups", "task_groups", groups)
+	w.prefetchTaskGroups(d, w.j, groups)
+}
+
 // updateDeployment is used to update the tracked deployment.
 func (w *deploymentWatcher) updateDeployment(d *structs.Deployment) {
 	w.l.Lock()
@@ -414,6 +442,10 @@ func (w *deploymentWatcher) StopWatch() {
 // failed and potentially rolling back the job. Progress can be made when an
 // allocation transitions to healthy, so we create an eval.
 func (w *deploymentWatcher) watch() {
+	// Start prefetching the task groups that ask for it, so later
+	// placements don't have to wait on their images and artifacts.
+	w.prefetch()
+
 	// Get the deadline. This is likely a zero time to begin with but we need to
 	// handle the case that the deployment has already progressed and we are now
 	// just starting to watch it. This must likely would occur if there was a
@@ -57,6 +57,15 @@ type DeploymentRaftEndpoints interface {
 	UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error)
 }
 
+// DeploymentPrefetcher is used to fetch the images and artifacts of a
+// deployment's task groups on the nodes where they could be placed.
+type DeploymentPrefetcher interface {
+	// PrefetchTaskGroups starts prefetching the task groups of the
+	// deployment and records the progress in the deployment. It must not
+	// block.
+	PrefetchTaskGroups(d *structs.Deployment, job *structs.Job, groups []string)
+}
+
 // Watcher is used to watch deployments and their allocations created
 // by the scheduler and trigger the scheduler when allocation health
 // transitions.
@@ -84,6 +93,10 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// prefetcher is used to prefetch task groups when deployments start. It
+	// may be nil.
+	prefetcher DeploymentPrefetcher
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -103,6 +116,7 @@ type Watcher struct {
 func NewDeploymentsWatcher(logger log.Logger,
 	raft DeploymentRaftEndpoints,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	prefetcher DeploymentPrefetcher,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
 ) *Watcher {
@@ -111,6 +125,7 @@ func NewDeploymentsWatcher(logger log.Logger,
 		raft:                raft,
 		deploymentRPC:       deploymentRPC,
 		jobRPC:              jobRPC,
+		prefetcher:          prefetcher,
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
 		logger:              logger.Named("deployments_watcher"),
@@ -482,3 +497,11 @@ func (w *Watcher) upsertDeploymentPromotion(req *structs.ApplyDeploymentPromoteR
 func (w *Watcher) upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	return w.raft.UpdateDeploymentAllocHealth(req)
 }
+
+// prefetchTaskGroups starts prefetching the task groups of a deployment.
+func (w *Watcher) prefetchTaskGroups(d *structs.Deployment, job *structs.Job, groups []string) {
+	if w.prefetcher == nil {
+		return
+	}
+	w.prefetcher.PrefetchTaskGroups(d, job, groups)
+}
@@ -0,0 +1,385 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// HostVolume is the server RPC endpoint for dynamic host volumes.
+type HostVolume struct {
+	srv    *Server
+	ctx    *RPCContext
+	logger log.Logger
+}
+
+func NewHostVolumeEndpoint(srv *Server, ctx *RPCContext) *HostVolume {
+	return &HostVolume{srv: srv, ctx: ctx, logger: srv.logger.Named("host_volume")}
+}
+
+// Get returns a single dynamic host volume.
+func (v *HostVolume) Get(args *structs.HostVolumeGetRequest, reply *structs.HostVolumeGetResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Get", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "get"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			vol, err := store.HostVolumeByID(ws, args.RequestNamespace(), args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Volume = vol
+			if vol != nil {
+				reply.Index = vol.ModifyIndex
+			} else {
+				index, err := store.Index(state.TableHostVolumes)
+				if err != nil {
+					return err
+				}
+				reply.Index = max(1, index)
+			}
+			v.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List returns the dynamic host volumes, optionally filtered by node or node
+// pool.
+func (v *HostVolume) List(args *structs.HostVolumeListRequest, reply *structs.HostVolumeListResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.List", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "list"}, time.Now())
+
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+
+	ns := args.RequestNamespace()
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			if args.NodeID != "" {
+				iter, err = store.HostVolumesByNodeID(ws, args.NodeID)
+			} else {
+				iter, err = store.HostVolumes(ws, state.SortDefault)
+			}
+			if err != nil {
+				return err
+			}
+
+			vols := []*structs.HostVolumeStub{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				vol := raw.(*structs.HostVolume)
+				if ns != structs.AllNamespacesSentinel && vol.Namespace != ns {
+					continue
+				}
+				if args.NodePool != "" && vol.NodePool != args.NodePool {
+					continue
+				}
+				if !allowVolume(aclObj, vol.Namespace) {
+					continue
+				}
+				vols = append(vols, vol.Stub())
+			}
+			reply.Volumes = vols
+
+			index, err := store.Index(state.TableHostVolumes)
+			if err != nil {
+				return err
+			}
+			reply.Index = max(1, index)
+			v.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// Create provisions a dynamic host volume on a node with the volume's plugin
+// and registers it in state.
+func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *structs.HostVolumeCreateResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Create", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "create"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeCreate)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+
+	if args.Volume == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing volume definition")
+	}
+
+	// This is the only namespace we ACL checked, force the volume to use it.
+	vol := args.Volume.Copy()
+	vol.Namespace = args.RequestNamespace()
+	if !allowVolume(aclObj, vol.Namespace) {
+		return structs.ErrPermissionDenied
+	}
+
+	vol.Canonicalize()
+	if err := vol.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+	if vol.ID != "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest,
+			"host volumes cannot be updated, only created")
+	}
+	vol.ID = uuid.Generate()
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	node, err := v.placeHostVolume(snap, vol)
+	if err != nil {
+		return fmt.Errorf("could not place volume %q: %w", vol.Name, err)
+	}
+	vol.NodeID = node.ID
+	vol.NodePool = node.NodePool
+
+	cReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        vol.ID,
+		Name:                      vol.Name,
+		PluginID:                  vol.PluginID,
+		NodeID:                    vol.NodeID,
+		RequestedCapacityMinBytes: vol.RequestedCapacityMinBytes,
+		RequestedCapacityMaxBytes: vol.RequestedCapacityMaxBytes,
+		Parameters:                vol.Parameters,
+	}
+	cResp := &cstructs.ClientHostVolumeCreateResponse{}
+	if err := v.srv.RPC("ClientHostVolume.Create", cReq, cResp); err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+	vol.HostPath = cResp.HostPath
+	vol.CapacityBytes = cResp.CapacityBytes
+	vol.CreateTime = now
+	vol.ModifyTime = now
+
+	regArgs := &structs.HostVolumeRegisterRequest{
+		Volumes:      []*structs.HostVolume{vol},
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := v.srv.raftApply(structs.HostVolumeRegisterRequestType, regArgs)
+	if err != nil {
+		v.logger.Error("raft apply failed", "error", err, "method", "register")
+		return err
+	}
+
+	// Read the volume back so the response has the state it was written with
+	reply.Volume, err = v.srv.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// placeHostVolume returns the node a new volume should be provisioned on:
+// either the node requested or a ready node in the volume's node pool that
+// satisfies its constraints and doesn't have a host volume with its name.
+func (v *HostVolume) placeHostVolume(snap *state.StateSnapshot, vol *structs.HostVolume) (*structs.Node, error) {
+	if vol.NodeID != "" {
+		node, err := snap.NodeByID(nil, vol.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("no such node %s", vol.NodeID)
+		}
+		if _, ok := node.HostVolumes[vol.Name]; ok {
+			return nil, fmt.Errorf("node %s already has a host volume named %q", node.ID, vol.Name)
+		}
+		return node, nil
+	}
+
+	iter, err := snap.NodesByNodePool(nil, vol.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := scheduler.NewEvalContext(nil, snap, &structs.Plan{}, v.logger)
+	checker := scheduler.NewConstraintChecker(ctx, vol.Constraints)
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+		if _, ok := node.HostVolumes[vol.Name]; ok {
+			continue
+		}
+		if !checker.Feasible(node) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return nil, errors.New("no node meets constraints")
+	}
+
+	// spread volumes out rather than always picking the first node
+	return nodes[rand.Intn(len(nodes))], nil
+}
+
+// Delete deletes dynamic host volumes from their nodes and from state. A
+// volume can't be deleted while allocations on its node may be using it.
+func (v *HostVolume) Delete(args *structs.HostVolumeDeleteRequest, reply *structs.HostVolumeDeleteResponse) error {
+	authErr := v.srv.Authenticate(v.ctx, args)
+	if done, err := v.srv.forward("HostVolume.Delete", args, args, reply); done {
+		return err
+	}
+	v.srv.MeasureRPCRate("host_volume", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "delete"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeDelete)
+	aclObj, err := v.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if len(args.VolumeIDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing volumes to delete")
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	var mErr multierror.Error
+	var deleted []string
+	for _, id := range args.VolumeIDs {
+		vol, err := snap.HostVolumeByID(nil, args.RequestNamespace(), id)
+		if err != nil {
+			return err
+		}
+		if vol == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("no such volume: %s", id))
+			continue
+		}
+
+		if err := v.checkVolumeInUse(snap, vol); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+			continue
+		}
+
+		cReq := &cstructs.ClientHostVolumeDeleteRequest{
+			ID:         vol.ID,
+			Name:       vol.Name,
+			PluginID:   vol.PluginID,
+			NodeID:     vol.NodeID,
+			HostPath:   vol.HostPath,
+			Parameters: vol.Parameters,
+		}
+		cResp := &cstructs.ClientHostVolumeDeleteResponse{}
+		if err := v.srv.RPC("ClientHostVolume.Delete", cReq, cResp); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("could not delete volume %s: %w", id, err))
+			continue
+		}
+		deleted = append(deleted, id)
+	}
+
+	if len(deleted) > 0 {
+		delArgs := &structs.HostVolumeDeleteRequest{
+			VolumeIDs:    deleted,
+			WriteRequest: args.WriteRequest,
+		}
+		_, index, err := v.srv.raftApply(structs.HostVolumeDeleteRequestType, delArgs)
+		if err != nil {
+			v.logger.Error("raft apply failed", "error", err, "method", "delete")
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		reply.Index = index
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// checkVolumeInUse returns an error if a non-terminal allocation on the
+// volume's node has a host volume request for it.
+func (v *HostVolume) checkVolumeInUse(snap *state.StateSnapshot, vol *structs.HostVolume) error {
+	allocs, err := snap.AllocsByNode(nil, vol.NodeID)
+	if err != nil {
+		return err
+	}
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() || alloc.Job == nil {
+			continue
+		}
+		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+		for _, req := range tg.Volumes {
+			if req.Type == structs.VolumeTypeHost && req.Source == vol.Name {
+				return fmt.Errorf("volume %s is in use by allocation %s", vol.ID, alloc.ID)
+			}
+		}
+	}
+	return nil
+}
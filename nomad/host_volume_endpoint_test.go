@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+	"github.com/shoenig/test/wait"
+)
+
+func TestHostVolumeEndpoint_CreateDelete(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	c1, cleanupC1 := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+	})
+	t.Cleanup(func() { cleanupC1() })
+	waitForNodes(t, srv, 1, 1)
+
+	vol := &structs.HostVolume{
+		Name:                      "example",
+		RequestedCapacityMinBytes: 1 << 20,
+	}
+
+	t.Run("invalid create", func(t *testing.T) {
+		req := &structs.HostVolumeCreateRequest{
+			Volume: &structs.HostVolume{Name: "bad/name"},
+			WriteRequest: structs.WriteRequest{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var resp structs.HostVolumeCreateResponse
+		err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+		must.ErrorContains(t, err, "invalid name")
+	})
+
+	var volID string
+	t.Run("create", func(t *testing.T) {
+		req := &structs.HostVolumeCreateRequest{
+			Volume: vol,
+			WriteRequest: structs.WriteRequest{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var resp structs.HostVolumeCreateResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp))
+		must.NotNil(t, resp.Volume)
+		must.UUIDv4(t, resp.Volume.ID)
+		must.Eq(t, c1.NodeID(), resp.Volume.NodeID)
+		must.Eq(t, structs.HostVolumePluginMkdir, resp.Volume.PluginID)
+		must.DirExists(t, resp.Volume.HostPath)
+		volID = resp.Volume.ID
+	})
+
+	t.Run("fingerprint", func(t *testing.T) {
+		must.Wait(t, wait.InitialSuccess(
+			wait.ErrorFunc(func() error {
+				vol, err := srv.State().HostVolumeByID(nil, structs.DefaultNamespace, volID)
+				if err != nil {
+					return err
+				}
+				if vol.State != structs.HostVolumeStateReady {
+					return fmt.Errorf("expected volume to be ready, got %q", vol.State)
+				}
+				return nil
+			}),
+			wait.Timeout(20*time.Second),
+			wait.Gap(100*time.Millisecond),
+		))
+
+		node, err := srv.State().NodeByID(nil, c1.NodeID())
+		must.NoError(t, err)
+		must.MapContainsKey(t, node.HostVolumes, vol.Name)
+		must.Eq(t, volID, node.HostVolumes[vol.Name].ID)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		req := &structs.HostVolumeCreateRequest{
+			Volume: &structs.HostVolume{Name: vol.Name, NodeID: c1.NodeID()},
+			WriteRequest: structs.WriteRequest{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var resp structs.HostVolumeCreateResponse
+		err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+		must.ErrorContains(t, err, "already has a host volume")
+	})
+
+	t.Run("get and list", func(t *testing.T) {
+		getReq := &structs.HostVolumeGetRequest{
+			ID: volID,
+			QueryOptions: structs.QueryOptions{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var getResp structs.HostVolumeGetResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp))
+		must.NotNil(t, getResp.Volume)
+		must.Eq(t, volID, getResp.Volume.ID)
+
+		listReq := &structs.HostVolumeListRequest{
+			NodeID: c1.NodeID(),
+			QueryOptions: structs.QueryOptions{
+				Region:    srv.Region(),
+				Namespace: structs.AllNamespacesSentinel},
+		}
+		var listResp structs.HostVolumeListResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+		must.Len(t, 1, listResp.Volumes)
+	})
+
+	t.Run("delete in use", func(t *testing.T) {
+		alloc := mock.Alloc()
+		alloc.NodeID = c1.NodeID()
+		alloc.Job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+			"example": {Name: "example", Type: structs.VolumeTypeHost, Source: vol.Name},
+		}
+		index, _ := srv.State().LatestIndex()
+		must.NoError(t, srv.State().UpsertJob(structs.MsgTypeTestSetup, index+1, nil, alloc.Job))
+		must.NoError(t, srv.State().UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+
+		req := &structs.HostVolumeDeleteRequest{
+			VolumeIDs: []string{volID},
+			WriteRequest: structs.WriteRequest{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var resp structs.HostVolumeDeleteResponse
+		err := msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", req, &resp)
+		must.ErrorContains(t, err, "in use by allocation")
+
+		alloc = alloc.Copy()
+		alloc.DesiredStatus = structs.AllocDesiredStatusStop
+		alloc.ClientStatus = structs.AllocClientStatusComplete
+		must.NoError(t, srv.State().UpsertAllocs(structs.MsgTypeTestSetup, index+3, []*structs.Allocation{alloc}))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		got, err := srv.State().HostVolumeByID(nil, structs.DefaultNamespace, volID)
+		must.NoError(t, err)
+
+		req := &structs.HostVolumeDeleteRequest{
+			VolumeIDs: []string{volID},
+			WriteRequest: structs.WriteRequest{
+				Region:    srv.Region(),
+				Namespace: structs.DefaultNamespace},
+		}
+		var resp structs.HostVolumeDeleteResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", req, &resp))
+		_, err = os.Stat(got.HostPath)
+		must.True(t, os.IsNotExist(err))
+
+		got, err = srv.State().HostVolumeByID(nil, structs.DefaultNamespace, volID)
+		must.NoError(t, err)
+		must.Nil(t, got)
+	})
+}
+
+func TestHostVolumeEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, rootToken, cleanupSrv := TestACLServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	readToken := mock.CreatePolicyAndToken(t, srv.State(), 1001, "host-volume-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "",
+			[]string{acl.NamespaceCapabilityHostVolumeRead}))
+
+	req := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{Name: "example", NodeID: uuid.Generate()},
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// with a valid token the request gets as far as placement
+	req.AuthToken = rootToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	must.ErrorContains(t, err, "no such node")
+
+	listReq := &structs.HostVolumeListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    srv.Region(),
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var listResp structs.HostVolumeListResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "HostVolume.List", listReq, &listResp))
+	must.Len(t, 0, listResp.Volumes)
+}
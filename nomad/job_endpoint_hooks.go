@@ -27,6 +27,7 @@ const (
 	attrLoopbackCNI       = `${attr.plugins.cni.version.loopback}`
 	attrPortMapCNI        = `${attr.plugins.cni.version.portmap}`
 	attrConsulCNI         = `${attr.plugins.cni.version.consul-cni}`
+	attrJavaJDKs          = `${attr.driver.java.jdks}`
 )
 
 // cniMinVersion is the version expression for the minimum CNI version supported
@@ -279,6 +280,9 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 
 	taskScheduleTaskGroups := j.RequiredScheduleTask()
 
+	// Identify which java tasks request a JDK version.
+	jdkVersions := j.RequiredJDKVersions()
+
 	// Hot path where none of our things require constraints.
 	//
 	// [UPDATE THIS] if you are adding a new constraint thing!
@@ -286,7 +290,7 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		nativeServiceDisco.Empty() && len(consulServiceDisco) == 0 &&
 		numaTaskGroups.Empty() && bridgeNetworkingTaskGroups.Empty() &&
 		transparentProxyTaskGroups.Empty() &&
-		taskScheduleTaskGroups.Empty() {
+		taskScheduleTaskGroups.Empty() && len(jdkVersions) == 0 {
 		return j, nil, nil
 	}
 
@@ -360,6 +364,16 @@ func (jobImpliedConstraints) Mutate(j *structs.Job) (*structs.Job, []error, erro
 		if taskScheduleTaskGroups.Contains(tg.Name) {
 			mutateConstraint(constraintMatcherLeft, tg, taskScheduleConstraint)
 		}
+
+		// If java tasks request a JDK version, constrain them to the clients
+		// with a matching JDK.
+		if tgJDKVersions, ok := jdkVersions[tg.Name]; ok {
+			for _, task := range tg.Tasks {
+				if v, ok := tgJDKVersions[task.Name]; ok {
+					mutateConstraint(constraintMatcherLeft, task, jdkVersionConstraintFn(v))
+				}
+			}
+		}
 	}
 
 	return j, nil, nil
@@ -398,6 +412,16 @@ func consulConstraintFn(service *structs.Service) *structs.Constraint {
 	return consulServiceDiscoveryConstraint
 }
 
+// jdkVersionConstraintFn returns a constraint that matches the clients where
+// the java driver fingerprinted a JDK of the requested version.
+func jdkVersionConstraintFn(version string) *structs.Constraint {
+	return &structs.Constraint{
+		LTarget: attrJavaJDKs,
+		RTarget: version,
+		Operand: structs.ConstraintSetContainsVersion,
+	}
+}
+
 // constraintMatcher is a custom type which helps control how constraints are
 // identified as being present within a task group.
 type constraintMatcher uint
@@ -1325,6 +1325,58 @@ func Test_jobImpliedConstraints_Mutate(t *testing.T) {
 			expectedOutputWarnings: nil,
 			expectedOutputError:    nil,
 		},
+		{
+			name: "java task with jdk version",
+			inputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Tasks: []*structs.Task{
+							{
+								Name:   "java-task",
+								Driver: "java",
+								Config: map[string]interface{}{"jdk_version": ">= 17"},
+							},
+							{
+								Name:   "exec-task",
+								Driver: "exec",
+								Config: map[string]interface{}{"jdk_version": ">= 17"},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputJob: &structs.Job{
+				Name: "example",
+				TaskGroups: []*structs.TaskGroup{
+					{
+						Name: "group1",
+						Tasks: []*structs.Task{
+							{
+								Name:   "java-task",
+								Driver: "java",
+								Config: map[string]interface{}{"jdk_version": ">= 17"},
+								Constraints: []*structs.Constraint{
+									{
+										LTarget: attrJavaJDKs,
+										RTarget: ">= 17",
+										Operand: structs.ConstraintSetContainsVersion,
+									},
+								},
+							},
+							{
+								Name:   "exec-task",
+								Driver: "exec",
+								Config: map[string]interface{}{"jdk_version": ">= 17"},
+							},
+						},
+					},
+				},
+			},
+			expectedOutputWarnings: nil,
+			expectedOutputError:    nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// prefetchConcurrency is the number of nodes prefetching a task group at the
+// same time.
+const prefetchConcurrency = 16
+
+// Prefetch asks the nodes where the job's task groups could be placed to fetch
+// their images and artifacts ahead of any allocation.
+func (j *Job) Prefetch(args *structs.JobPrefetchRequest, reply *structs.JobPrefetchResponse) error {
+	authErr := j.srv.Authenticate(j.ctx, args)
+	if done, err := j.srv.forward("Job.Prefetch", args, args, reply); done {
+		return err
+	}
+	j.srv.MeasureRPCRate("job", structs.RateMetricWrite, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "prefetch"}, time.Now())
+
+	aclObj, err := j.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.JobID == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing job ID")
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return structs.NewErrRPCCoded(http.StatusNotFound, fmt.Sprintf("job %q not found", args.JobID))
+	}
+
+	var groups []string
+	if args.TaskGroup != "" {
+		if job.LookupTaskGroup(args.TaskGroup) == nil {
+			return structs.NewErrRPCCoded(http.StatusBadRequest,
+				fmt.Sprintf("job %q has no task group %q", job.ID, args.TaskGroup))
+		}
+		groups = []string{args.TaskGroup}
+	} else {
+		for _, tg := range job.TaskGroups {
+			groups = append(groups, tg.Name)
+		}
+	}
+
+	reply.Nodes, err = j.srv.prefetchJob(job, groups, "")
+	if err != nil {
+		return err
+	}
+	reply.Index, err = snap.LatestIndex()
+	return err
+}
+
+// prefetchJob asks the nodes where the job's task groups could be placed to
+// fetch their images and artifacts, and blocks until they are done. If
+// deploymentID is set, the progress of each task group is recorded in the
+// deployment.
+func (s *Server) prefetchJob(job *structs.Job, groups []string, deploymentID string) ([]*structs.JobPrefetchNodeResult, error) {
+	snap, err := s.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*structs.JobPrefetchNodeResult
+	for _, name := range groups {
+		tg := job.LookupTaskGroup(name)
+		if tg == nil {
+			return nil, fmt.Errorf("job %q has no task group %q", job.ID, name)
+		}
+		nodes, err := prefetchCandidates(snap, job, tg, s.logger)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, s.prefetchTaskGroup(job, tg, nodes, deploymentID)...)
+	}
+	return results, nil
+}
+
+// prefetchCandidates returns the ready nodes where the task group could be
+// placed, according to the scheduler's constraint and driver checks.
+func prefetchCandidates(snap *state.StateSnapshot, job *structs.Job, tg *structs.TaskGroup, logger log.Logger) ([]*structs.Node, error) {
+	iter, err := snap.NodesByNodePool(nil, job.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints := slices.Concat(job.Constraints, tg.Constraints)
+	drivers := make(map[string]struct{}, len(tg.Tasks))
+	for _, task := range tg.Tasks {
+		constraints = append(constraints, task.Constraints...)
+		drivers[task.Driver] = struct{}{}
+	}
+
+	ctx := scheduler.NewEvalContext(nil, snap, &structs.Plan{}, logger)
+	checkers := []scheduler.FeasibilityChecker{
+		scheduler.NewConstraintChecker(ctx, constraints),
+		scheduler.NewDriverChecker(ctx, drivers),
+	}
+
+	var nodes []*structs.Node
+NODES:
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() || !node.IsInAnyDC(job.Datacenters) {
+			continue
+		}
+		for _, checker := range checkers {
+			if !checker.Feasible(node) {
+				continue NODES
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// prefetchTaskGroup asks each node to prefetch the task group.
+func (s *Server) prefetchTaskGroup(job *structs.Job, tg *structs.TaskGroup, nodes []*structs.Node, deploymentID string) []*structs.JobPrefetchNodeResult {
+	var lock sync.Mutex
+	prefetched := 0
+
+	// recordProgress must be called with the lock held
+	recordProgress := func() {
+		if deploymentID == "" {
+			return
+		}
+		req := &structs.ApplyDeploymentPrefetchRequest{
+			DeploymentID: deploymentID,
+			TaskGroup:    tg.Name,
+			Nodes:        len(nodes),
+			Prefetched:   prefetched,
+			WriteRequest: structs.WriteRequest{Region: s.Region(), Namespace: job.Namespace},
+		}
+		if _, _, err := s.raftApply(structs.DeploymentPrefetchRequestType, req); err != nil {
+			s.logger.Warn("failed to record deployment prefetch progress",
+				"deployment_id", deploymentID, "task_group", tg.Name, "error", err)
+		}
+	}
+	recordProgress()
+
+	results := make([]*structs.JobPrefetchNodeResult, len(nodes))
+	sem := make(chan struct{}, prefetchConcurrency)
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			result := &structs.JobPrefetchNodeResult{NodeID: node.ID, TaskGroup: tg.Name}
+			err := s.RPC("ClientPrefetch.TaskGroup", &cstructs.ClientPrefetchRequest{
+				NodeID:    node.ID,
+				Job:       job,
+				TaskGroup: tg.Name,
+			}, &cstructs.ClientPrefetchResponse{})
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				s.logger.Warn("failed to prefetch task group", "job", job.NamespacedID(),
+					"task_group", tg.Name, "node_id", node.ID, "error", err)
+				result.Error = err.Error()
+			} else {
+				prefetched++
+				recordProgress()
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
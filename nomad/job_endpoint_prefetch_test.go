@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestJobEndpoint_Prefetch(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	c1, cleanupC1 := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+	})
+	t.Cleanup(func() { cleanupC1() })
+	waitForNodes(t, srv, 1, 1)
+
+	job := mock.Job()
+	job.Constraints = nil
+	job.TaskGroups[0].Tasks[0].Driver = "mock_driver"
+	job.TaskGroups[0].Tasks[0].Config = map[string]any{"run_for": "1s"}
+	job.TaskGroups[0].Tasks[0].Constraints = nil
+	must.NoError(t, srv.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	req := &structs.JobPrefetchRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: job.Namespace,
+		},
+	}
+
+	t.Run("prefetch", func(t *testing.T) {
+		var resp structs.JobPrefetchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Prefetch", req, &resp))
+		must.Len(t, 1, resp.Nodes)
+		must.Eq(t, c1.NodeID(), resp.Nodes[0].NodeID)
+		must.Eq(t, job.TaskGroups[0].Name, resp.Nodes[0].TaskGroup)
+		must.Eq(t, "", resp.Nodes[0].Error)
+	})
+
+	t.Run("no candidate nodes", func(t *testing.T) {
+		other := job.Copy()
+		other.ID = "other"
+		other.Datacenters = []string{"other"}
+		must.NoError(t, srv.State().UpsertJob(structs.MsgTypeTestSetup, 1001, nil, other))
+
+		req := *req
+		req.JobID = other.ID
+		var resp structs.JobPrefetchResponse
+		must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Prefetch", &req, &resp))
+		must.Len(t, 0, resp.Nodes)
+	})
+
+	t.Run("unknown task group", func(t *testing.T) {
+		req := *req
+		req.TaskGroup = "unknown"
+		var resp structs.JobPrefetchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Prefetch", &req, &resp)
+		must.ErrorContains(t, err, `has no task group "unknown"`)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		req := *req
+		req.JobID = "unknown"
+		var resp structs.JobPrefetchResponse
+		err := msgpackrpc.CallWithCodec(codec, "Job.Prefetch", &req, &resp)
+		must.ErrorContains(t, err, "not found")
+	})
+}
+
+func TestJobEndpoint_Prefetch_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, root, cleanupSrv := TestACLServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	t.Cleanup(cleanupSrv)
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	job := mock.Job()
+	must.NoError(t, srv.State().UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	readToken := mock.CreatePolicyAndToken(t, srv.State(), 1001, "read-job",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	req := &structs.JobPrefetchRequest{
+		JobID: job.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    srv.Region(),
+			Namespace: job.Namespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var resp structs.JobPrefetchResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Prefetch", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	// there are no nodes, so nothing is prefetched
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Prefetch", req, &resp))
+	must.Len(t, 0, resp.Nodes)
+}
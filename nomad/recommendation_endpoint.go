@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"net/http"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Recommendation endpoint is used to query the resource recommendations
+// generated from the OOM history and peak memory usage of tasks.
+type Recommendation struct {
+	srv *Server
+	ctx *RPCContext
+}
+
+func NewRecommendationEndpoint(srv *Server, ctx *RPCContext) *Recommendation {
+	return &Recommendation{srv: srv, ctx: ctx}
+}
+
+// List is used to list the recommendations for a namespace, optionally
+// filtered down to a job, group and task.
+func (r *Recommendation) List(args *structs.RecommendationListRequest, reply *structs.RecommendationListResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.List", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricList, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "list"}, time.Now())
+
+	if args.Group != "" && args.JobID == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "job must be set when filtering by group")
+	}
+	if args.Task != "" && args.Group == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "group must be set when filtering by task")
+	}
+
+	namespace := args.RequestNamespace()
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+	allow := aclObj.AllowNsOpFunc(acl.NamespaceCapabilityReadJob)
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			reply.Recommendations = make([]*structs.Recommendation, 0)
+
+			allowableNamespaces, err := allowedNSes(aclObj, store, allow)
+			if err != nil && err != structs.ErrPermissionDenied {
+				return err
+			} else if err == nil {
+				recs, err := jobRecommendations(ws, store, namespace, args.JobID)
+				if err != nil {
+					return err
+				}
+				for _, rec := range recs {
+					if allowableNamespaces != nil && !allowableNamespaces[rec.Namespace] {
+						continue
+					}
+					if args.Group != "" && rec.Group != args.Group {
+						continue
+					}
+					if args.Task != "" && rec.Task != args.Task {
+						continue
+					}
+					reply.Recommendations = append(reply.Recommendations, rec)
+				}
+			}
+
+			index, err := recommendationsIndex(store)
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			r.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// GetRecommendation returns the specific recommendation requested or nil if
+// the recommendation doesn't exist.
+func (r *Recommendation) GetRecommendation(args *structs.RecommendationSpecificRequest, reply *structs.SingleRecommendationResponse) error {
+	authErr := r.srv.Authenticate(r.ctx, args)
+	if done, err := r.srv.forward("Recommendation.GetRecommendation", args, args, reply); done {
+		return err
+	}
+	r.srv.MeasureRPCRate("recommendation", structs.RateMetricRead, args)
+	if authErr != nil {
+		return structs.ErrPermissionDenied
+	}
+	defer metrics.MeasureSince([]string{"nomad", "recommendation", "get_recommendation"}, time.Now())
+
+	namespace := args.RequestNamespace()
+	aclObj, err := r.srv.ResolveACL(args)
+	if err != nil {
+		return err
+	}
+	if !aclObj.AllowNsOp(namespace, acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, store *state.StateStore) error {
+			reply.Recommendation = nil
+
+			recs, err := jobRecommendations(ws, store, namespace, "")
+			if err != nil {
+				return err
+			}
+			for _, rec := range recs {
+				if rec.ID == args.RecommendationID {
+					reply.Recommendation = rec
+					break
+				}
+			}
+
+			index, err := recommendationsIndex(store)
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+
+			r.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		}}
+	return r.srv.blockingRPC(&opts)
+}
+
+// jobRecommendations computes the recommendations for the given job, or for
+// all jobs in the namespace if jobID is empty.
+func jobRecommendations(ws memdb.WatchSet, store *state.StateStore, namespace, jobID string) ([]*structs.Recommendation, error) {
+	var jobs []*structs.Job
+	if jobID != "" {
+		job, err := store.JobByID(ws, namespace, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job != nil {
+			jobs = append(jobs, job)
+		}
+	} else {
+		var iter memdb.ResultIterator
+		var err error
+		if namespace == structs.AllNamespacesSentinel {
+			iter, err = store.Jobs(ws, state.SortDefault)
+		} else {
+			iter, err = store.JobsByNamespace(ws, namespace, state.SortDefault)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			jobs = append(jobs, raw.(*structs.Job))
+		}
+	}
+
+	var recs []*structs.Recommendation
+	for _, job := range jobs {
+		allocs, err := store.AllocsByJob(ws, job.Namespace, job.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, structs.MemoryRecommendations(job, allocs)...)
+	}
+	return recs, nil
+}
+
+// recommendationsIndex returns the last index that affected the inputs of
+// generated recommendations.
+func recommendationsIndex(store *state.StateStore) (uint64, error) {
+	jindex, err := store.Index("jobs")
+	if err != nil {
+		return 0, err
+	}
+	aindex, err := store.Index("allocs")
+	if err != nil {
+		return 0, err
+	}
+	return max(1, jindex, aindex), nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc/v2"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/shoenig/test/must"
+)
+
+func TestRecommendationEndpoint_List(t *testing.T) {
+	ci.Parallel(t)
+
+	s, root, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+
+	codec := rpcClient(t, s)
+	testutil.WaitForLeader(t, s.RPC)
+	store := s.fsm.State()
+
+	job := mock.Job()
+	must.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 1000, nil, job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.TaskStates = map[string]*structs.TaskState{
+		"web": {
+			State:           structs.TaskStateDead,
+			OOMKills:        1,
+			PeakMemoryUsage: 512 * 1024 * 1024,
+		},
+	}
+	must.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	readToken := mock.CreatePolicyAndToken(t, store, 1002, "read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+	noPolicyToken := mock.CreateToken(t, store, 1003, nil)
+
+	req := &structs.RecommendationListRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: noPolicyToken.SecretID,
+		},
+	}
+	var resp structs.RecommendationListResponse
+	err := msgpackrpc.CallWithCodec(codec, "Recommendation.List", req, &resp)
+	must.EqError(t, err, structs.ErrPermissionDenied.Error())
+
+	req.AuthToken = readToken.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.List", req, &resp))
+	must.Len(t, 1, resp.Recommendations)
+	rec := resp.Recommendations[0]
+	must.Eq(t, job.ID, rec.JobID)
+	must.Eq(t, "web", rec.Task)
+	must.Eq(t, structs.RecommendationResourceMemory, rec.Resource)
+	must.Eq(t, 640, rec.Value)
+	must.Eq(t, 1001, resp.Index)
+
+	// Filtering by a group without OOM kills returns no recommendations.
+	req.Group = "other"
+	req.AuthToken = root.SecretID
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.List", req, &resp))
+	must.Len(t, 0, resp.Recommendations)
+
+	// The recommendation can be read back by ID.
+	getReq := &structs.RecommendationSpecificRequest{
+		RecommendationID: rec.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var getResp structs.SingleRecommendationResponse
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", getReq, &getResp))
+	must.Eq(t, rec, getResp.Recommendation)
+
+	getReq.RecommendationID = "does-not-exist"
+	must.NoError(t, msgpackrpc.CallWithCodec(codec, "Recommendation.GetRecommendation", getReq, &getResp))
+	must.Nil(t, getResp.Recommendation)
+}
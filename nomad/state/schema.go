@@ -26,6 +26,7 @@ const (
 	TableACLBindingRules      = "acl_binding_rules"
 	TableAllocs               = "allocs"
 	TableJobSubmission        = "job_submission"
+	TableHostVolumes          = "host_volumes"
 )
 
 const (
@@ -69,6 +70,7 @@ func init() {
 		indexTableSchema,
 		nodeTableSchema,
 		nodePoolTableSchema,
+		hostVolumeTableSchema,
 		jobTableSchema,
 		jobSummarySchema,
 		jobVersionSchema,
@@ -1638,3 +1640,39 @@ func bindingRulesTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// hostVolumeTableSchema returns the MemDB schema for dynamic host volumes.
+func hostVolumeTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableHostVolumes,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The volume ID in combination with namespace forms a unique
+			// identifier for a host volume.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field:     "ID",
+							Lowercase: true,
+						},
+					},
+				},
+			},
+			indexNodeID: {
+				Name:         indexNodeID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field:     "NodeID",
+					Lowercase: true,
+				},
+			},
+		},
+	}
+}
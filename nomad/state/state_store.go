@@ -1030,6 +1030,9 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 	if err := upsertCSIPluginsForNode(txn, node, index); err != nil {
 		return fmt.Errorf("csi plugin update failed: %v", err)
 	}
+	if err := upsertHostVolumesForNode(txn, node, index); err != nil {
+		return fmt.Errorf("host volume update failed: %v", err)
+	}
 
 	return nil
 }
@@ -1069,6 +1072,9 @@ func deleteNodeTxn(txn *txn, index uint64, nodes []string) error {
 		if err := deleteNodeCSIPlugins(txn, node, index); err != nil {
 			return fmt.Errorf("csi plugin delete failed: %v", err)
 		}
+		if err := deleteHostVolumesByNodeTxn(txn, index, nodeID); err != nil {
+			return fmt.Errorf("host volume delete failed: %v", err)
+		}
 	}
 
 	if err := txn.Insert("index", &IndexEntry{"nodes", index}); err != nil {
@@ -5126,6 +5132,41 @@ func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, in
 	return txn.Commit()
 }
 
+// UpdateDeploymentPrefetch is used to record the progress of the prefetch of
+// a deployment's task group.
+func (s *StateStore) UpdateDeploymentPrefetch(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentPrefetchRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	ws := memdb.NewWatchSet()
+	deployment, err := s.deploymentByIDImpl(ws, req.DeploymentID, txn)
+	if err != nil {
+		return err
+	} else if deployment == nil {
+		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", req.DeploymentID)
+	} else if !deployment.Active() {
+		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
+	}
+
+	copy := deployment.Copy()
+	dstate, ok := copy.TaskGroups[req.TaskGroup]
+	if !ok {
+		return fmt.Errorf("Deployment %q has no task group %q", deployment.ID, req.TaskGroup)
+	}
+	dstate.PrefetchNodes = req.Nodes
+	dstate.PrefetchedNodes = req.Prefetched
+	copy.ModifyIndex = index
+
+	if err := txn.Insert("deployment", copy); err != nil {
+		return err
+	}
+	if err := txn.Insert("index", &IndexEntry{"deployment", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // LatestIndex returns the greatest index value for all indexes.
 func (s *StateStore) LatestIndex() (uint64, error) {
 	indexes, err := s.Indexes()
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolumeByID retrieves a specific dynamic host volume.
+func (s *StateStore) HostVolumeByID(ws memdb.WatchSet, namespace, id string) (*structs.HostVolume, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, obj, err := txn.FirstWatch(TableHostVolumes, indexID, namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if obj == nil {
+		return nil, nil
+	}
+	return obj.(*structs.HostVolume), nil
+}
+
+// HostVolumes returns an iterator over all dynamic host volumes.
+func (s *StateStore) HostVolumes(ws memdb.WatchSet, sort SortOption) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	var iter memdb.ResultIterator
+	var err error
+	switch sort {
+	case SortReverse:
+		iter, err = txn.GetReverse(TableHostVolumes, indexID)
+	default:
+		iter, err = txn.Get(TableHostVolumes, indexID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// HostVolumesByNodeID returns an iterator over the dynamic host volumes
+// provisioned on the node.
+func (s *StateStore) HostVolumesByNodeID(ws memdb.WatchSet, nodeID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+	return iter, nil
+}
+
+// UpsertHostVolumes inserts or updates dynamic host volumes. A volume is
+// ready as soon as its node has fingerprinted it.
+func (s *StateStore) UpsertHostVolumes(msgType structs.MessageType, index uint64, volumes []*structs.HostVolume) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, vol := range volumes {
+		existing, err := txn.First(TableHostVolumes, indexID, vol.Namespace, vol.ID)
+		if err != nil {
+			return fmt.Errorf("host volume lookup failed: %v", err)
+		}
+
+		if existing != nil {
+			vol.CreateIndex = existing.(*structs.HostVolume).CreateIndex
+		} else {
+			vol.CreateIndex = index
+		}
+		vol.ModifyIndex = index
+
+		raw, err := txn.First("nodes", "id", vol.NodeID)
+		if err != nil {
+			return fmt.Errorf("node lookup failed: %v", err)
+		}
+		if raw == nil {
+			return fmt.Errorf("host volume %s has nonexistent node %s", vol.ID, vol.NodeID)
+		}
+		if hostVolumeFingerprinted(raw.(*structs.Node), vol) {
+			vol.State = structs.HostVolumeStateReady
+		} else {
+			vol.State = structs.HostVolumeStatePending
+		}
+
+		if err := txn.Insert(TableHostVolumes, vol); err != nil {
+			return fmt.Errorf("host volume insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// DeleteHostVolumes deletes dynamic host volumes by ID.
+func (s *StateStore) DeleteHostVolumes(msgType structs.MessageType, index uint64, namespace string, ids []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, id := range ids {
+		existing, err := txn.First(TableHostVolumes, indexID, namespace, id)
+		if err != nil {
+			return fmt.Errorf("host volume lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("host volume not found: %s", id)
+		}
+		if err := txn.Delete(TableHostVolumes, existing); err != nil {
+			return fmt.Errorf("host volume delete failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// upsertHostVolumesForNode marks the dynamic host volumes of the node ready
+// once the node has fingerprinted them.
+func upsertHostVolumesForNode(txn *txn, node *structs.Node, index uint64) error {
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, node.ID)
+	if err != nil {
+		return err
+	}
+
+	var updated bool
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vol := raw.(*structs.HostVolume)
+		if vol.State == structs.HostVolumeStateReady || !hostVolumeFingerprinted(node, vol) {
+			continue
+		}
+
+		vol = vol.Copy()
+		vol.State = structs.HostVolumeStateReady
+		vol.ModifyIndex = index
+		if err := txn.Insert(TableHostVolumes, vol); err != nil {
+			return err
+		}
+		updated = true
+	}
+
+	if updated {
+		if err := txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// deleteHostVolumesByNodeTxn deletes the dynamic host volumes of a node that
+// is being removed from state.
+func deleteHostVolumesByNodeTxn(txn *txn, index uint64, nodeID string) error {
+	iter, err := txn.Get(TableHostVolumes, indexNodeID, nodeID)
+	if err != nil {
+		return err
+	}
+
+	var vols []*structs.HostVolume
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vols = append(vols, raw.(*structs.HostVolume))
+	}
+	if len(vols) == 0 {
+		return nil
+	}
+
+	for _, vol := range vols {
+		if err := txn.Delete(TableHostVolumes, vol); err != nil {
+			return err
+		}
+	}
+	return txn.Insert(tableIndex, &IndexEntry{TableHostVolumes, index})
+}
+
+// hostVolumeFingerprinted returns true if the node has fingerprinted the
+// dynamic host volume.
+func hostVolumeFingerprinted(node *structs.Node, vol *structs.HostVolume) bool {
+	hv, ok := node.HostVolumes[vol.Name]
+	return ok && hv.ID == vol.ID
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/shoenig/test/must"
+)
+
+func TestStateStore_HostVolumes_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	store := testStateStore(t)
+	index, err := store.LatestIndex()
+	must.NoError(t, err)
+
+	node0, node1 := mock.Node(), mock.Node()
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node0))
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node1))
+
+	vol0 := &structs.HostVolume{
+		ID:        uuid.Generate(),
+		Name:      "example",
+		Namespace: structs.DefaultNamespace,
+		PluginID:  structs.HostVolumePluginMkdir,
+		NodeID:    node0.ID,
+		NodePool:  node0.NodePool,
+	}
+	vol1 := vol0.Copy()
+	vol1.ID = uuid.Generate()
+	vol1.NodeID = node1.ID
+
+	index++
+	must.NoError(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, index,
+		[]*structs.HostVolume{vol0, vol1}))
+
+	// volumes start out pending until their node fingerprints them
+	ws := memdb.NewWatchSet()
+	got, err := store.HostVolumeByID(ws, vol0.Namespace, vol0.ID)
+	must.NoError(t, err)
+	must.NotNil(t, got)
+	must.Eq(t, structs.HostVolumeStatePending, got.State)
+	must.Eq(t, index, got.CreateIndex)
+
+	iter, err := store.HostVolumesByNodeID(ws, node1.ID)
+	must.NoError(t, err)
+	must.Len(t, 1, collectHostVolumes(iter))
+
+	// upserting a volume on a missing node fails
+	badVol := vol0.Copy()
+	badVol.ID = uuid.Generate()
+	badVol.NodeID = uuid.Generate()
+	must.Error(t, store.UpsertHostVolumes(structs.MsgTypeTestSetup, index+1,
+		[]*structs.HostVolume{badVol}))
+
+	// fingerprinting the volume on the node marks it ready
+	node0 = node0.Copy()
+	node0.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		vol0.Name: {Name: vol0.Name, ID: vol0.ID, Path: "/tmp/example"},
+	}
+	index++
+	must.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, index, node0))
+	must.True(t, watchFired(ws))
+
+	got, err = store.HostVolumeByID(nil, vol0.Namespace, vol0.ID)
+	must.NoError(t, err)
+	must.Eq(t, structs.HostVolumeStateReady, got.State)
+	must.Eq(t, index, got.ModifyIndex)
+
+	// deleting a volume
+	index++
+	must.NoError(t, store.DeleteHostVolumes(structs.MsgTypeTestSetup, index,
+		vol0.Namespace, []string{vol0.ID}))
+	got, err = store.HostVolumeByID(nil, vol0.Namespace, vol0.ID)
+	must.NoError(t, err)
+	must.Nil(t, got)
+
+	// deleting the node garbage collects its volumes
+	index++
+	must.NoError(t, store.DeleteNode(structs.MsgTypeTestSetup, index, []string{node1.ID}))
+	iter, err = store.HostVolumes(nil, SortDefault)
+	must.NoError(t, err)
+	must.Len(t, 0, collectHostVolumes(iter))
+
+	tableIndex, err := store.Index(TableHostVolumes)
+	must.NoError(t, err)
+	must.Eq(t, index, tableIndex)
+}
+
+func collectHostVolumes(iter memdb.ResultIterator) []*structs.HostVolume {
+	vols := []*structs.HostVolume{}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		vols = append(vols, raw.(*structs.HostVolume))
+	}
+	return vols
+}
@@ -8446,6 +8446,41 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 }
 
 // Test that allocation health can't be set against a nonexistent deployment
+func TestStateStore_UpdateDeploymentPrefetch(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	d := mock.Deployment()
+	must.NoError(t, state.UpsertDeployment(1, d))
+
+	req := &structs.ApplyDeploymentPrefetchRequest{
+		DeploymentID: d.ID,
+		TaskGroup:    "web",
+		Nodes:        3,
+		Prefetched:   2,
+	}
+	must.NoError(t, state.UpdateDeploymentPrefetch(structs.MsgTypeTestSetup, 2, req))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	must.NoError(t, err)
+	must.Eq(t, 3, out.TaskGroups["web"].PrefetchNodes)
+	must.Eq(t, 2, out.TaskGroups["web"].PrefetchedNodes)
+	must.Eq(t, 2, out.ModifyIndex)
+
+	index, err := state.Index("deployment")
+	must.NoError(t, err)
+	must.Eq(t, 2, index)
+
+	// unknown task groups and deployments are rejected
+	req.TaskGroup = "unknown"
+	err = state.UpdateDeploymentPrefetch(structs.MsgTypeTestSetup, 3, req)
+	must.ErrorContains(t, err, "has no task group")
+
+	req.DeploymentID = uuid.Generate()
+	err = state.UpdateDeploymentPrefetch(structs.MsgTypeTestSetup, 3, req)
+	must.ErrorContains(t, err, "does not exist")
+}
+
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	ci.Parallel(t)
 
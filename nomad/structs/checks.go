@@ -93,3 +93,16 @@ func NomadCheckID(allocID, group string, c *ServiceCheck) CheckID {
 	h := sum.Sum(nil)
 	return CheckID(fmt.Sprintf("%x", h))
 }
+
+// DriverCheckID returns the deterministic ID of the check reporting the health
+// of a task as determined by its task driver, such as the HEALTHCHECK of a
+// Docker image.
+func DriverCheckID(allocID, group, task string) CheckID {
+	sum := md5.New()
+	hashString(sum, allocID)
+	hashString(sum, group)
+	hashString(sum, task)
+	hashString(sum, "driver")
+	h := sum.Sum(nil)
+	return CheckID(fmt.Sprintf("%x", h))
+}
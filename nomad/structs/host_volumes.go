@@ -0,0 +1,225 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	// HostVolumeStatePending is the state of a dynamic host volume that has
+	// been created but not yet fingerprinted by its node.
+	HostVolumeStatePending = "pending"
+
+	// HostVolumeStateReady is the state of a dynamic host volume that has
+	// been fingerprinted by its node and can be scheduled.
+	HostVolumeStateReady = "ready"
+
+	// HostVolumePluginMkdir is the built-in host volume plugin that creates a
+	// directory on the client.
+	HostVolumePluginMkdir = "mkdir"
+)
+
+// validHostVolumeName matches the names of volumes that can be created
+// through the API. The name ends up in the path of the volume on the client,
+// so it's restricted to characters that are safe in paths.
+var validHostVolumeName = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,128}$")
+
+// HostVolume is a host volume created through the API, rather than declared
+// in the client configuration. The volume is provisioned on a single node by
+// a host volume plugin and then fingerprinted by that node so it can be
+// scheduled like a static host volume.
+type HostVolume struct {
+	// ID is a UUID generated by the server when the volume is created.
+	ID string
+
+	// Name is the name the volume is requested by in a job's volume block.
+	// It must be unique on the node.
+	Name string
+
+	Namespace string
+
+	// PluginID is the host volume plugin used to provision the volume. It
+	// defaults to the built-in "mkdir" plugin.
+	PluginID string
+
+	// NodePool and Constraints are used to select a node when NodeID is not
+	// set on create.
+	NodePool    string
+	Constraints []*Constraint
+
+	// NodeID is the node the volume was provisioned on.
+	NodeID string
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are passed to
+	// the plugin, which reports the provisioned capacity in CapacityBytes.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+	CapacityBytes             int64
+
+	// Parameters are opaque key/values passed to the plugin.
+	Parameters map[string]string
+
+	// HostPath is the path of the volume on the node.
+	HostPath string
+
+	// State is the state of the volume, one of the HostVolumeState*
+	// constants.
+	State string
+
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// Copy returns a deep copy of the host volume.
+func (hv *HostVolume) Copy() *HostVolume {
+	if hv == nil {
+		return nil
+	}
+
+	nhv := *hv
+	nhv.Constraints = CopySliceConstraints(hv.Constraints)
+	nhv.Parameters = maps.Clone(hv.Parameters)
+	return &nhv
+}
+
+// Canonicalize sets the defaults of an incoming volume.
+func (hv *HostVolume) Canonicalize() {
+	if hv.Namespace == "" {
+		hv.Namespace = DefaultNamespace
+	}
+	if hv.PluginID == "" {
+		hv.PluginID = HostVolumePluginMkdir
+	}
+	if hv.NodePool == "" && hv.NodeID == "" {
+		hv.NodePool = NodePoolDefault
+	}
+}
+
+// Validate validates an incoming volume before it's created.
+func (hv *HostVolume) Validate() error {
+	var mErr *multierror.Error
+
+	if !validHostVolumeName.MatchString(hv.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q", hv.Name))
+	}
+	if hv.PluginID == "" {
+		mErr = multierror.Append(mErr, errors.New("missing plugin ID"))
+	}
+	if hv.RequestedCapacityMinBytes < 0 || hv.RequestedCapacityMaxBytes < 0 {
+		mErr = multierror.Append(mErr, errors.New("capacity cannot be negative"))
+	}
+	if hv.RequestedCapacityMaxBytes > 0 &&
+		hv.RequestedCapacityMaxBytes < hv.RequestedCapacityMinBytes {
+		mErr = multierror.Append(mErr, errors.New("capacity_max must be greater than or equal to capacity_min"))
+	}
+	for idx, constr := range hv.Constraints {
+		if err := constr.Validate(); err != nil {
+			mErr = multierror.Append(mErr, fmt.Errorf("constraint %d validation failed: %v", idx+1, err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Stub returns a list stub of the volume.
+func (hv *HostVolume) Stub() *HostVolumeStub {
+	if hv == nil {
+		return nil
+	}
+	return &HostVolumeStub{
+		ID:            hv.ID,
+		Name:          hv.Name,
+		Namespace:     hv.Namespace,
+		PluginID:      hv.PluginID,
+		NodePool:      hv.NodePool,
+		NodeID:        hv.NodeID,
+		CapacityBytes: hv.CapacityBytes,
+		State:         hv.State,
+		CreateIndex:   hv.CreateIndex,
+		CreateTime:    hv.CreateTime,
+		ModifyIndex:   hv.ModifyIndex,
+		ModifyTime:    hv.ModifyTime,
+	}
+}
+
+// HostVolumeStub is used for listing host volumes.
+type HostVolumeStub struct {
+	ID            string
+	Name          string
+	Namespace     string
+	PluginID      string
+	NodePool      string
+	NodeID        string
+	CapacityBytes int64
+	State         string
+
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// HostVolumeCreateRequest is used to create a host volume.
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+// HostVolumeCreateResponse is the response to a host volume create request.
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
+
+// HostVolumeRegisterRequest is the Raft request used to write a host volume
+// to state once it has been provisioned.
+type HostVolumeRegisterRequest struct {
+	Volumes []*HostVolume
+	WriteRequest
+}
+
+// HostVolumeDeleteRequest is used to delete a host volume.
+type HostVolumeDeleteRequest struct {
+	VolumeIDs []string
+	WriteRequest
+}
+
+// HostVolumeDeleteResponse is the response to a host volume delete request.
+type HostVolumeDeleteResponse struct {
+	WriteMeta
+}
+
+// HostVolumeGetRequest is used to read a single host volume.
+type HostVolumeGetRequest struct {
+	ID string
+	QueryOptions
+}
+
+// HostVolumeGetResponse is the response to a host volume get request.
+type HostVolumeGetResponse struct {
+	Volume *HostVolume
+	QueryMeta
+}
+
+// HostVolumeListRequest is used to list host volumes, optionally filtered
+// down to a node or node pool.
+type HostVolumeListRequest struct {
+	NodeID   string
+	NodePool string
+	QueryOptions
+}
+
+// HostVolumeListResponse is the response to a host volume list request.
+type HostVolumeListResponse struct {
+	Volumes []*HostVolumeStub
+	QueryMeta
+}
@@ -279,6 +279,26 @@ func (j *Job) RequiredTransparentProxy() set.Collection[string] {
 	return result
 }
 
+// RequiredJDKVersions returns the JDK version constraints of the java tasks
+// within the job, keyed by task group and task name.
+func (j *Job) RequiredJDKVersions() map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for _, tg := range j.TaskGroups {
+		for _, t := range tg.Tasks {
+			if t.Driver != "java" {
+				continue
+			}
+			if v, ok := t.Config["jdk_version"].(string); ok && v != "" {
+				if result[tg.Name] == nil {
+					result[tg.Name] = make(map[string]string)
+				}
+				result[tg.Name][t.Name] = v
+			}
+		}
+	}
+	return result
+}
+
 // RequiredScheduleTask collects any groups within the job that have
 // tasks with a schedule{} block for time based task execution (Enterprise)
 func (j *Job) RequiredScheduleTask() set.Collection[string] {
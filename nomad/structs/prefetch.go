@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+// JobPrefetchRequest is used to fetch the images and artifacts of a job's
+// task groups on the nodes where they could be placed.
+type JobPrefetchRequest struct {
+	JobID string
+
+	// TaskGroup limits the prefetch to a single task group. All task groups
+	// are prefetched if it's empty.
+	TaskGroup string
+
+	WriteRequest
+}
+
+// JobPrefetchResponse is the response to a JobPrefetchRequest.
+type JobPrefetchResponse struct {
+	// Nodes is the result of the prefetch on each candidate node.
+	Nodes []*JobPrefetchNodeResult
+
+	WriteMeta
+}
+
+// JobPrefetchNodeResult is the result of prefetching a task group on a node.
+type JobPrefetchNodeResult struct {
+	NodeID    string
+	TaskGroup string
+
+	// Error is set if the node failed to prefetch the task group.
+	Error string
+}
+
+// ApplyDeploymentPrefetchRequest is used to record the progress of the
+// prefetch of a deployment's task group via Raft.
+type ApplyDeploymentPrefetchRequest struct {
+	DeploymentID string
+	TaskGroup    string
+
+	// Nodes is the number of candidate nodes asked to prefetch the task
+	// group, and Prefetched the number of them that finished.
+	Nodes      int
+	Prefetched int
+
+	WriteRequest
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// RecommendationResourceMemory is the resource name used by
+	// recommendations that suggest a new value for the task's memory.
+	RecommendationResourceMemory = "MemoryMB"
+
+	// RecommendationResourceMemoryMax is the resource name used by
+	// recommendations that suggest a new value for the task's memory_max.
+	RecommendationResourceMemoryMax = "MemoryMaxMB"
+
+	// RecommendationMemoryHeadroom is the fraction of the observed peak memory
+	// usage added on top of it when recommending a new memory value after an
+	// OOM kill.
+	RecommendationMemoryHeadroom = 0.25
+)
+
+// Recommendation is a suggested resource value for a task. In the community
+// edition recommendations are derived from the OOM history and peak memory
+// usage reported by clients in the task states of a job's allocations.
+type Recommendation struct {
+	ID         string
+	Region     string
+	Namespace  string
+	JobID      string
+	JobVersion uint64
+	Group      string
+	Task       string
+	Resource   string
+	Value      int
+	Current    int
+	Meta       map[string]interface{}
+	Stats      map[string]float64
+
+	// EnforceVersion is always false for generated recommendations.
+	EnforceVersion bool
+
+	SubmitTime int64
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// recommendationID returns a stable UUID-formatted ID for a recommendation so
+// that recommendations computed on demand can be looked up again by ID.
+func recommendationID(namespace, jobID, group, task, resource string) string {
+	sum := sha256.Sum256([]byte(namespace + "\x00" + jobID + "\x00" +
+		group + "\x00" + task + "\x00" + resource))
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%12x",
+		sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// taskMemoryHistory aggregates the memory history of a single task across
+// allocations.
+type taskMemoryHistory struct {
+	peak      uint64
+	oomKills  uint64
+	lastIndex uint64
+	submit    int64
+}
+
+// MemoryRecommendations returns the memory recommendations for the job based
+// on the task states of the passed allocations. A recommendation is only
+// generated for tasks that have been OOM killed while running the current
+// version of the job. Tasks with memory oversubscription enabled receive a
+// memory_max recommendation, other tasks receive a memory recommendation.
+func MemoryRecommendations(job *Job, allocs []*Allocation) []*Recommendation {
+	if job == nil {
+		return nil
+	}
+
+	histories := map[string]map[string]*taskMemoryHistory{}
+	for _, alloc := range allocs {
+		if alloc.JobID != job.ID || alloc.Namespace != job.Namespace ||
+			alloc.Job == nil || alloc.Job.Version != job.Version {
+			continue
+		}
+		for taskName, ts := range alloc.TaskStates {
+			if ts == nil || (ts.OOMKills == 0 && ts.PeakMemoryUsage == 0) {
+				continue
+			}
+			tasks, ok := histories[alloc.TaskGroup]
+			if !ok {
+				tasks = map[string]*taskMemoryHistory{}
+				histories[alloc.TaskGroup] = tasks
+			}
+			h, ok := tasks[taskName]
+			if !ok {
+				h = &taskMemoryHistory{}
+				tasks[taskName] = h
+			}
+			h.peak = max(h.peak, ts.PeakMemoryUsage)
+			h.oomKills += ts.OOMKills
+			h.lastIndex = max(h.lastIndex, alloc.ModifyIndex)
+			h.submit = max(h.submit, alloc.ModifyTime)
+		}
+	}
+
+	var recs []*Recommendation
+	for _, tg := range job.TaskGroups {
+		for _, task := range tg.Tasks {
+			h := histories[tg.Name][task.Name]
+			if h == nil || h.oomKills == 0 || task.Resources == nil {
+				continue
+			}
+
+			resource := RecommendationResourceMemory
+			current := task.Resources.MemoryMB
+			if task.Resources.MemoryMaxMB > 0 {
+				resource = RecommendationResourceMemoryMax
+				current = task.Resources.MemoryMaxMB
+			}
+
+			// The task was killed for exceeding its limit, so the observed
+			// peak is a lower bound of what it actually needed.
+			peakMB := float64(h.peak) / 1024 / 1024
+			value := int(math.Ceil(max(peakMB, float64(current)) * (1 + RecommendationMemoryHeadroom)))
+
+			recs = append(recs, &Recommendation{
+				ID:         recommendationID(job.Namespace, job.ID, tg.Name, task.Name, resource),
+				Region:     job.Region,
+				Namespace:  job.Namespace,
+				JobID:      job.ID,
+				JobVersion: job.Version,
+				Group:      tg.Name,
+				Task:       task.Name,
+				Resource:   resource,
+				Value:      value,
+				Current:    current,
+				Stats: map[string]float64{
+					"peak_mb":   peakMB,
+					"oom_kills": float64(h.oomKills),
+				},
+				SubmitTime:  h.submit,
+				CreateIndex: h.lastIndex,
+				ModifyIndex: h.lastIndex,
+			})
+		}
+	}
+
+	slices.SortFunc(recs, func(a, b *Recommendation) int {
+		return cmp.Or(strings.Compare(a.Group, b.Group), strings.Compare(a.Task, b.Task))
+	})
+	return recs
+}
+
+// RecommendationListRequest is used to list the recommendations for a
+// namespace, optionally filtered down to a job, group and task.
+type RecommendationListRequest struct {
+	JobID string
+	Group string
+	Task  string
+	QueryOptions
+}
+
+// RecommendationListResponse is the response to a recommendations list
+// request.
+type RecommendationListResponse struct {
+	Recommendations []*Recommendation
+	QueryMeta
+}
+
+// RecommendationSpecificRequest is used to make a request for a specific
+// recommendation.
+type RecommendationSpecificRequest struct {
+	RecommendationID string
+	QueryOptions
+}
+
+// SingleRecommendationResponse is the response to a specific recommendation
+// request.
+type SingleRecommendationResponse struct {
+	Recommendation *Recommendation
+	QueryMeta
+}
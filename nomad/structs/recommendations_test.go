@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: BUSL-1.1
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/shoenig/test/must"
+)
+
+func TestMemoryRecommendations(t *testing.T) {
+	ci.Parallel(t)
+
+	job := &Job{
+		ID:        "example",
+		Namespace: DefaultNamespace,
+		Region:    "global",
+		Version:   2,
+		TaskGroups: []*TaskGroup{
+			{
+				Name: "web",
+				Tasks: []*Task{
+					{Name: "app", Resources: &Resources{MemoryMB: 256}},
+					{Name: "sidecar", Resources: &Resources{MemoryMB: 128}},
+				},
+			},
+			{
+				Name: "cache",
+				Tasks: []*Task{
+					{Name: "redis", Resources: &Resources{MemoryMB: 256, MemoryMaxMB: 512}},
+				},
+			},
+		},
+	}
+
+	oldJob := job.Copy()
+	oldJob.Version = 1
+
+	newAlloc := func(group string, j *Job, states map[string]*TaskState) *Allocation {
+		return &Allocation{
+			Namespace:   j.Namespace,
+			JobID:       j.ID,
+			Job:         j,
+			TaskGroup:   group,
+			TaskStates:  states,
+			ModifyIndex: 10,
+		}
+	}
+
+	allocs := []*Allocation{
+		newAlloc("web", job, map[string]*TaskState{
+			"app":     {OOMKills: 1, PeakMemoryUsage: 300 * 1024 * 1024},
+			"sidecar": {PeakMemoryUsage: 64 * 1024 * 1024},
+		}),
+		newAlloc("web", job, map[string]*TaskState{
+			"app": {OOMKills: 2, PeakMemoryUsage: 200 * 1024 * 1024},
+		}),
+		newAlloc("cache", job, map[string]*TaskState{
+			"redis": {OOMKills: 1, PeakMemoryUsage: 100 * 1024 * 1024},
+		}),
+		// OOM kills from previous job versions are ignored.
+		newAlloc("web", oldJob, map[string]*TaskState{
+			"sidecar": {OOMKills: 5, PeakMemoryUsage: 128 * 1024 * 1024},
+		}),
+	}
+
+	recs := MemoryRecommendations(job, allocs)
+	must.Len(t, 2, recs)
+
+	redis := recs[0]
+	must.Eq(t, "cache", redis.Group)
+	must.Eq(t, "redis", redis.Task)
+	must.Eq(t, RecommendationResourceMemoryMax, redis.Resource)
+	must.Eq(t, 512, redis.Current)
+	must.Eq(t, 640, redis.Value)
+	must.Eq(t, 1, redis.Stats["oom_kills"])
+
+	app := recs[1]
+	must.Eq(t, "web", app.Group)
+	must.Eq(t, "app", app.Task)
+	must.Eq(t, RecommendationResourceMemory, app.Resource)
+	must.Eq(t, 256, app.Current)
+	must.Eq(t, 375, app.Value)
+	must.Eq(t, 3, app.Stats["oom_kills"])
+	must.Eq(t, 300, app.Stats["peak_mb"])
+	must.Eq(t, 2, app.JobVersion)
+
+	// IDs are stable so recommendations can be looked up again.
+	again := MemoryRecommendations(job, allocs)
+	must.Eq(t, app.ID, again[1].ID)
+	must.NotEq(t, app.ID, redis.ID)
+}
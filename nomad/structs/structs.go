@@ -132,6 +132,11 @@ const (
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
 	NamespaceDeleteRequestType MessageType = 65
+
+	HostVolumeRegisterRequestType MessageType = 66
+	HostVolumeDeleteRequestType   MessageType = 67
+
+	DeploymentPrefetchRequestType MessageType = 68
 )
 
 const (
@@ -5271,6 +5276,10 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// Prefetch declares that the task group's images and artifacts should
+	// be fetched on candidate nodes when a deployment starts.
+	Prefetch bool
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -8961,6 +8970,14 @@ type TaskState struct {
 	// Enterprise Only - Paused is set to the paused state of the task. See
 	// task_sched.go
 	Paused TaskScheduleState
+
+	// PeakMemoryUsage is the highest memory usage in bytes observed by the
+	// client's stats collection for this task across restarts.
+	PeakMemoryUsage uint64
+
+	// OOMKills is the number of times the task was killed for running out of
+	// memory.
+	OOMKills uint64
 }
 
 // NewTaskState returns a TaskState initialized in the Pending state.
@@ -9030,6 +9047,12 @@ func (ts *TaskState) Equal(o *TaskState) bool {
 	if !ts.TaskHandle.Equal(o.TaskHandle) {
 		return false
 	}
+	if ts.PeakMemoryUsage != o.PeakMemoryUsage {
+		return false
+	}
+	if ts.OOMKills != o.OOMKills {
+		return false
+	}
 
 	return true
 }
@@ -9756,16 +9779,17 @@ func (ta *TaskArtifact) validateChecksum() error {
 }
 
 const (
-	ConstraintDistinctProperty  = "distinct_property"
-	ConstraintDistinctHosts     = "distinct_hosts"
-	ConstraintRegex             = "regexp"
-	ConstraintVersion           = "version"
-	ConstraintSemver            = "semver"
-	ConstraintSetContains       = "set_contains"
-	ConstraintSetContainsAll    = "set_contains_all"
-	ConstraintSetContainsAny    = "set_contains_any"
-	ConstraintAttributeIsSet    = "is_set"
-	ConstraintAttributeIsNotSet = "is_not_set"
+	ConstraintDistinctProperty   = "distinct_property"
+	ConstraintDistinctHosts      = "distinct_hosts"
+	ConstraintRegex              = "regexp"
+	ConstraintVersion            = "version"
+	ConstraintSemver             = "semver"
+	ConstraintSetContains        = "set_contains"
+	ConstraintSetContainsAll     = "set_contains_all"
+	ConstraintSetContainsAny     = "set_contains_any"
+	ConstraintSetContainsVersion = "set_contains_version"
+	ConstraintAttributeIsSet     = "is_set"
+	ConstraintAttributeIsNotSet  = "is_not_set"
 )
 
 // A Constraint is used to restrict placement options.
@@ -9828,6 +9852,10 @@ func (c *Constraint) Validate() error {
 		if _, err := semver.NewConstraint(c.RTarget); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Semver constraint is invalid: %v", err))
 		}
+	case ConstraintSetContainsVersion:
+		if _, err := version.NewConstraint(c.RTarget); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Version constraint is invalid: %v", err))
+		}
 	case ConstraintDistinctProperty:
 		// If a count is set, make sure it is convertible to a uint64
 		if c.RTarget != "" {
@@ -9949,6 +9977,10 @@ func (a *Affinity) Validate() error {
 		if _, err := semver.NewConstraint(a.RTarget); err != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Semver affinity is invalid: %v", err))
 		}
+	case ConstraintSetContainsVersion:
+		if _, err := version.NewConstraint(a.RTarget); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Version affinity is invalid: %v", err))
+		}
 	case "=", "==", "is", "!=", "not", "<", "<=", ">", ">=":
 		if a.RTarget == "" {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("Operator %q requires an RTarget", a.Operand))
@@ -10575,6 +10607,18 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// Prefetch marks that images and artifacts should be fetched on
+	// candidate nodes when the deployment starts.
+	Prefetch bool
+
+	// PrefetchNodes is the number of candidate nodes asked to prefetch the
+	// task group's images and artifacts.
+	PrefetchNodes int
+
+	// PrefetchedNodes is the number of candidate nodes that finished
+	// prefetching.
+	PrefetchedNodes int
 }
 
 func (d *DeploymentState) GoString() string {
@@ -10587,6 +10631,7 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	base += fmt.Sprintf("\n\tPrefetched: %d/%d", d.PrefetchedNodes, d.PrefetchNodes)
 	return base
 }
 
@@ -31,6 +31,11 @@ type ClientHostVolumeConfig struct {
 	Name     string `hcl:",key"`
 	Path     string `hcl:"path"`
 	ReadOnly bool   `hcl:"read_only"`
+
+	// ID is set for dynamic host volumes created through the API and is the
+	// ID of the HostVolume. It is empty for host volumes from the client
+	// configuration.
+	ID string `hcl:"-"`
 }
 
 func (p *ClientHostVolumeConfig) Copy() *ClientHostVolumeConfig {
@@ -98,6 +98,14 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// DriverPrefetcher is the interface implemented by drivers that can fetch
+// what a task needs to start, such as its image, before the task is placed on
+// the node. Only built-in drivers support prefetching, since it isn't part of
+// the plugin protocol.
+type DriverPrefetcher interface {
+	PrefetchTask(*TaskConfig) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -503,6 +511,15 @@ func (r *ExitResult) Copy() *ExitResult {
 	return res
 }
 
+// TaskHealthStatusAttr and TaskHealthOutputAttr are the driver attributes of a
+// task status reporting the health of the task as determined by its runtime,
+// such as the HEALTHCHECK of a Docker image. The status is one of the Nomad
+// check statuses: pending, success or failure.
+const (
+	TaskHealthStatusAttr = "health_status"
+	TaskHealthOutputAttr = "health_output"
+)
+
 type TaskStatus struct {
 	ID               string
 	Name             string
@@ -862,6 +862,9 @@ func checkConstraint(ctx Context, operand string, lVal, rVal interface{}, lFound
 		return lFound && rFound && checkSetContainsAll(ctx, lVal, rVal)
 	case structs.ConstraintSetContainsAny:
 		return lFound && rFound && checkSetContainsAny(lVal, rVal)
+	case structs.ConstraintSetContainsVersion:
+		parser := newVersionConstraintParser(ctx)
+		return lFound && rFound && checkSetContainsVersion(ctx, parser, lVal, rVal)
 	default:
 		return false
 	}
@@ -1109,6 +1112,24 @@ func checkSetContainsAny(lVal, rVal interface{}) bool {
 	return false
 }
 
+// checkSetContainsVersion is used to see if any of the versions in the
+// comma-separated left hand side matches the constraints on the right hand
+// side
+func checkSetContainsVersion(ctx Context, parse verConstraintParser, lVal, rVal interface{}) bool {
+	// Ensure left-hand is string
+	lStr, ok := lVal.(string)
+	if !ok {
+		return false
+	}
+
+	for _, l := range strings.Split(lStr, ",") {
+		if checkVersionMatch(ctx, parse, strings.TrimSpace(l), rVal) {
+			return true
+		}
+	}
+	return false
+}
+
 // FeasibilityWrapper is a FeasibleIterator which wraps both job and task group
 // FeasibilityCheckers in which feasibility checking can be skipped if the
 // computed node class has previously been marked as eligible or ineligible.
@@ -1124,6 +1124,21 @@ func TestCheckConstraint(t *testing.T) {
 			lVal: "foo,bar,baz", rVal: "foo,bam",
 			result: false,
 		},
+		{
+			op:   structs.ConstraintSetContainsVersion,
+			lVal: "11.0.21, 17.0.9,21.0.1", rVal: ">= 17, < 21",
+			result: true,
+		},
+		{
+			op:   structs.ConstraintSetContainsVersion,
+			lVal: "8.0.392,11.0.21", rVal: ">= 17",
+			result: false,
+		},
+		{
+			op:   structs.ConstraintSetContainsVersion,
+			lVal: nil, rVal: ">= 17",
+			result: false,
+		},
 		{
 			op:     structs.ConstraintAttributeIsSet,
 			lVal:   "foo",
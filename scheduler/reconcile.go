@@ -637,6 +637,7 @@ func (a *allocReconciler) initializeDeploymentState(group string, tg *structs.Ta
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
+			dstate.Prefetch = tg.Update.Prefetch
 		}
 	}
 
@@ -293,8 +293,8 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 		if c := consulUpdated(at.Consul, bt.Consul); c.modified {
 			return c
 		}
-		if !slices.EqualFunc(at.Templates, bt.Templates, func(a, b *structs.Template) bool { return a.Equal(b) }) {
-			return difference("task templates", at.Templates, bt.Templates)
+		if c := templatesUpdated(at.Templates, bt.Templates); c.modified {
+			return c
 		}
 		if !at.CSIPluginConfig.Equal(bt.CSIPluginConfig) {
 			return difference("task csi config", at.CSIPluginConfig, bt.CSIPluginConfig)
@@ -635,6 +635,52 @@ func spreadsUpdated(jobA, jobB *structs.Job, taskGroup string) comparison {
 	return same
 }
 
+// templatesUpdated returns the difference in task templates that requires a
+// destructive update. Templates that only change their contents and whose
+// change_mode is signal, script or noop are reloaded in place by the client's
+// template hook, which re-renders them and applies their change_mode.
+func templatesUpdated(a, b []*structs.Template) comparison {
+	if len(a) != len(b) {
+		return difference("task templates", a, b)
+	}
+	for i := range a {
+		if a[i].Equal(b[i]) {
+			continue
+		}
+		if !templateReloadable(a[i], b[i]) {
+			return difference("task templates", a, b)
+		}
+	}
+	return same
+}
+
+// templateReloadable returns true if the template can be re-rendered in place.
+// Only the contents of the template may change: its destination, permissions,
+// env file handling and change_mode must stay the same.
+func templateReloadable(a, b *structs.Template) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	switch a.ChangeMode {
+	case structs.TemplateChangeModeSignal,
+		structs.TemplateChangeModeScript,
+		structs.TemplateChangeModeNoop:
+	default:
+		return false
+	}
+
+	withoutContents := func(t *structs.Template) *structs.Template {
+		t = t.Copy()
+		t.SourcePath, t.EmbeddedTmpl = "", ""
+		t.LeftDelim, t.RightDelim = "", ""
+		t.Splay, t.VaultGrace = 0, 0
+		t.Wait = nil
+		t.ErrMissingKey = false
+		return t
+	}
+	return withoutContents(a).Equal(withoutContents(b))
+}
+
 // renderTemplatesUpdated returns the difference in the RestartPolicy's
 // render_templates field, if set
 func renderTemplatesUpdated(a, b *structs.RestartPolicy, msg string) comparison {
@@ -1245,6 +1245,53 @@ func TestTasksUpdated_NUMA(t *testing.T) {
 	must.True(t, tasksUpdated(j1, j2, name).modified)
 }
 
+func TestTasksUpdated_Templates(t *testing.T) {
+	ci.Parallel(t)
+
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+	j1.TaskGroups[0].Tasks[0].Templates = []*structs.Template{
+		{
+			EmbeddedTmpl: "foo={{ key \"foo\" }}",
+			DestPath:     "local/foo.env",
+			ChangeMode:   structs.TemplateChangeModeSignal,
+			ChangeSignal: "SIGHUP",
+			Perms:        "0644",
+		},
+	}
+
+	j2 := j1.Copy()
+	must.False(t, tasksUpdated(j1, j2, name).modified)
+
+	// Changing the contents of a signal template is reloaded in place
+	j2.TaskGroups[0].Tasks[0].Templates[0].EmbeddedTmpl = "foo={{ key \"bar\" }}"
+	j2.TaskGroups[0].Tasks[0].Templates[0].Splay = 10 * time.Second
+	must.False(t, tasksUpdated(j1, j2, name).modified)
+
+	// Changing where the template is rendered is destructive
+	j3 := j2.Copy()
+	j3.TaskGroups[0].Tasks[0].Templates[0].DestPath = "local/bar.env"
+	must.True(t, tasksUpdated(j1, j3, name).modified)
+
+	// Changing the change_mode is destructive
+	j4 := j2.Copy()
+	j4.TaskGroups[0].Tasks[0].Templates[0].ChangeMode = structs.TemplateChangeModeRestart
+	must.True(t, tasksUpdated(j1, j4, name).modified)
+
+	// Changing the contents of a restart template is destructive
+	j5 := j1.Copy()
+	j5.TaskGroups[0].Tasks[0].Templates[0].ChangeMode = structs.TemplateChangeModeRestart
+	j6 := j5.Copy()
+	j6.TaskGroups[0].Tasks[0].Templates[0].EmbeddedTmpl = "foo=bar"
+	must.True(t, tasksUpdated(j5, j6, name).modified)
+
+	// Adding a template is destructive
+	j7 := j1.Copy()
+	j7.TaskGroups[0].Tasks[0].Templates = append(j7.TaskGroups[0].Tasks[0].Templates,
+		&structs.Template{EmbeddedTmpl: "bar", DestPath: "local/bar"})
+	must.True(t, tasksUpdated(j1, j7, name).modified)
+}
+
 func TestTaskGroupConstraints(t *testing.T) {
 	ci.Parallel(t)
 